@@ -0,0 +1,134 @@
+package respond
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultCachePolicy is the cache policy of responses that have not been
+// given one. It is nil, sending no Cache-Control header, unless set.
+var DefaultCachePolicy *CachePolicy
+
+// CachePolicy builds the value of a Cache-Control header
+type CachePolicy struct {
+	public         bool
+	private        bool
+	noCache        bool
+	noStore        bool
+	mustRevalidate bool
+	immutable      bool
+
+	maxAge               *time.Duration
+	sharedMaxAge         *time.Duration
+	staleWhileRevalidate *time.Duration
+	staleIfError         *time.Duration
+}
+
+// NewCachePolicy creates and returns an empty cache policy
+func NewCachePolicy() *CachePolicy {
+	return &CachePolicy{}
+}
+
+// Public allows shared caches to store the response
+func (p *CachePolicy) Public() *CachePolicy {
+	p.public, p.private = true, false
+	return p
+}
+
+// Private restricts storing the response to private caches
+func (p *CachePolicy) Private() *CachePolicy {
+	p.private, p.public = true, false
+	return p
+}
+
+// NoCache requires caches to revalidate the response before every use
+func (p *CachePolicy) NoCache() *CachePolicy {
+	p.noCache = true
+	return p
+}
+
+// NoStore forbids caches from storing the response
+func (p *CachePolicy) NoStore() *CachePolicy {
+	p.noStore = true
+	return p
+}
+
+// MustRevalidate forbids caches from using the response once stale
+func (p *CachePolicy) MustRevalidate() *CachePolicy {
+	p.mustRevalidate = true
+	return p
+}
+
+// Immutable indicates the response will not change while fresh
+func (p *CachePolicy) Immutable() *CachePolicy {
+	p.immutable = true
+	return p
+}
+
+// MaxAge sets how long the response stays fresh
+func (p *CachePolicy) MaxAge(d time.Duration) *CachePolicy {
+	p.maxAge = &d
+	return p
+}
+
+// SharedMaxAge sets how long the response stays fresh in shared caches
+func (p *CachePolicy) SharedMaxAge(d time.Duration) *CachePolicy {
+	p.sharedMaxAge = &d
+	return p
+}
+
+// StaleWhileRevalidate sets how long a stale response may be used while it
+// is revalidated in the background
+func (p *CachePolicy) StaleWhileRevalidate(d time.Duration) *CachePolicy {
+	p.staleWhileRevalidate = &d
+	return p
+}
+
+// StaleIfError sets how long a stale response may be used when revalidating
+// it fails
+func (p *CachePolicy) StaleIfError(d time.Duration) *CachePolicy {
+	p.staleIfError = &d
+	return p
+}
+
+// String renders the policy as a Cache-Control header value
+func (p *CachePolicy) String() string {
+	var directives []string
+
+	flag := func(set bool, name string) {
+		if set {
+			directives = append(directives, name)
+		}
+	}
+	seconds := func(d *time.Duration, name string) {
+		if d != nil {
+			secs := int64(*d / time.Second)
+			if secs < 0 {
+				secs = 0
+			}
+			directives = append(directives, name+"="+strconv.FormatInt(secs, 10))
+		}
+	}
+
+	flag(p.public, "public")
+	flag(p.private, "private")
+	flag(p.noCache, "no-cache")
+	flag(p.noStore, "no-store")
+	seconds(p.maxAge, "max-age")
+	seconds(p.sharedMaxAge, "s-maxage")
+	flag(p.mustRevalidate, "must-revalidate")
+	flag(p.immutable, "immutable")
+	seconds(p.staleWhileRevalidate, "stale-while-revalidate")
+	seconds(p.staleIfError, "stale-if-error")
+
+	return strings.Join(directives, ", ")
+}
+
+// Cache sets the cache policy of the response, overriding
+// DefaultCachePolicy. The policy is sent in the Cache-Control header of
+// successful and redirect responses.
+func (resp *Response) Cache(p *CachePolicy) *Response {
+	resp.CachePolicy = p
+	return resp
+}
@@ -0,0 +1,103 @@
+package respond
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+var cachePolicyData = []struct {
+	testName string
+
+	policy *CachePolicy
+
+	expected string
+}{
+	{"empty", NewCachePolicy(), ""},
+	{"public max-age", NewCachePolicy().Public().MaxAge(time.Hour), "public, max-age=3600"},
+	{"private overrides public", NewCachePolicy().Public().Private(), "private"},
+	{"no-store", NewCachePolicy().NoStore(), "no-store"},
+	{"zero max-age", NewCachePolicy().NoCache().MaxAge(0), "no-cache, max-age=0"},
+	{"shared", NewCachePolicy().Public().MaxAge(time.Minute).SharedMaxAge(10 * time.Minute), "public, max-age=60, s-maxage=600"},
+	{"immutable", NewCachePolicy().Public().MaxAge(365 * 24 * time.Hour).Immutable(), "public, max-age=31536000, immutable"},
+	{"stale", NewCachePolicy().MaxAge(time.Minute).MustRevalidate().StaleWhileRevalidate(30 * time.Second).StaleIfError(time.Hour),
+		"max-age=60, must-revalidate, stale-while-revalidate=30, stale-if-error=3600"},
+}
+
+func TestCachePolicy(t *testing.T) {
+	for _, datum := range cachePolicyData {
+		datum := datum
+		t.Run(datum.testName, func(t *testing.T) {
+			t.Parallel()
+
+			if err := validateResponseHeader(datum.policy.String(), datum.expected); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestCacheHeader(t *testing.T) {
+	t.Parallel()
+
+	req := newRequest(t, "GET")
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		NewResponse(w).
+			Cache(NewCachePolicy().Private().MaxAge(time.Minute)).
+			Ok(nil)
+	})
+	handler.ServeHTTP(rr, req)
+
+	if err := validateResponseHeader(rr.Header().Get("Cache-Control"), "private, max-age=60"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCacheHeaderOnError(t *testing.T) {
+	t.Parallel()
+
+	req := newRequest(t, "GET")
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		NewResponse(w).
+			Cache(NewCachePolicy().Public().MaxAge(time.Hour)).
+			NotFound(nil)
+	})
+	handler.ServeHTTP(rr, req)
+
+	if err := validateResponseHeader(rr.Header().Get("Cache-Control"), ""); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestDefaultCachePolicy is not parallel as it changes the package default
+func TestDefaultCachePolicy(t *testing.T) {
+	DefaultCachePolicy = NewCachePolicy().NoStore()
+	defer func() { DefaultCachePolicy = nil }()
+
+	req := newRequest(t, "GET")
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		NewResponse(w).Ok(nil)
+	})
+	handler.ServeHTTP(rr, req)
+
+	if err := validateResponseHeader(rr.Header().Get("Cache-Control"), "no-store"); err != nil {
+		t.Fatal(err)
+	}
+
+	rr = httptest.NewRecorder()
+	handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		NewResponse(w).Cache(NewCachePolicy().Public()).Ok(nil)
+	})
+	handler.ServeHTTP(rr, req)
+
+	if err := validateResponseHeader(rr.Header().Get("Cache-Control"), "public"); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,166 @@
+package respond
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"strings"
+	"sync"
+)
+
+// DefaultCompressMinSize is the smallest body, in bytes, compressed when
+// Compress is given a size of zero
+const DefaultCompressMinSize = 1024
+
+var gzipWriters = sync.Pool{
+	New: func() interface{} { return gzip.NewWriter(nil) },
+}
+
+var zlibWriters = sync.Pool{
+	New: func() interface{} { return zlib.NewWriter(nil) },
+}
+
+// compressor is a pooled writer producing a single content coding
+type compressor interface {
+	io.WriteCloser
+	Reset(w io.Writer)
+}
+
+// contentCodings are the supported content codings in order of preference.
+// The HTTP deflate coding is the zlib format.
+var contentCodings = []struct {
+	name string
+	pool *sync.Pool
+}{
+	{"gzip", &gzipWriters},
+	{"deflate", &zlibWriters},
+}
+
+// incompressibleTypes are media types whose content is already compressed
+var incompressibleTypes = map[string]bool{
+	"application/gzip":             true,
+	"application/x-gzip":           true,
+	"application/zip":              true,
+	"application/x-bzip2":          true,
+	"application/x-7z-compressed":  true,
+	"application/x-rar-compressed": true,
+	"application/zstd":             true,
+	"application/pdf":              true,
+	"font/woff":                    true,
+	"font/woff2":                   true,
+}
+
+// Compress enables gzip or deflate compression of bodies of at least minSize
+// bytes, as accepted by the request's Accept-Encoding header. A minSize of
+// zero uses DefaultCompressMinSize. Compression needs the request to be set
+// with WithRequest.
+func (resp *Response) Compress(minSize int) *Response {
+	if minSize <= 0 {
+		minSize = DefaultCompressMinSize
+	}
+	resp.Compression = true
+	resp.CompressMinSize = minSize
+	return resp
+}
+
+// contentCoding returns the index of the content coding the body is
+// compressed with, or -1 when it is sent as it is: when it is too small,
+// already encoded or of a content type that is already compressed, or when
+// the request accepts no supported coding. The Vary header is set whenever
+// compression is enabled.
+func (resp *Response) contentCoding(body []byte) int {
+	if !resp.Compression || resp.Request == nil {
+		return -1
+	}
+
+	header := resp.Writer.Header()
+	addVary(header, "Accept-Encoding")
+
+	if len(body) < resp.CompressMinSize || header.Get("Content-Encoding") != "" ||
+		!compressible(header.Get("Content-Type")) {
+		return -1
+	}
+
+	return preferredCoding(strings.Join(resp.Request.Header["Accept-Encoding"], ","))
+}
+
+// compress returns the body compressed with the content coding, setting the
+// Content-Encoding header. The body is returned unchanged if there is no
+// coding or compressing it fails.
+func (resp *Response) compress(body []byte, coding int) []byte {
+	if coding < 0 || body == nil {
+		return body
+	}
+
+	pool := contentCodings[coding].pool
+	w := pool.Get().(compressor)
+	defer pool.Put(w)
+
+	var buf bytes.Buffer
+	w.Reset(&buf)
+	if _, err := w.Write(body); err != nil {
+		return body
+	}
+	if err := w.Close(); err != nil {
+		return body
+	}
+
+	header := resp.Writer.Header()
+	header.Set("Content-Encoding", contentCodings[coding].name)
+	header.Del("Content-Length")
+	return buf.Bytes()
+}
+
+// preferredCoding returns the index of the supported content coding with
+// the highest quality in the Accept-Encoding header, or -1 if none is
+// acceptable
+func preferredCoding(acceptEncoding string) int {
+	if strings.TrimSpace(acceptEncoding) == "" {
+		return -1
+	}
+
+	values := parseAccept(acceptEncoding)
+
+	best, bestQ := -1, 0.0
+	for i, coding := range contentCodings {
+		q, matched := 0.0, false
+		for _, v := range values {
+			if v.value == coding.name {
+				q, matched = v.q, true
+				break
+			}
+		}
+		if !matched {
+			for _, v := range values {
+				if v.value == "*" {
+					q = v.q
+					break
+				}
+			}
+		}
+
+		if q > bestQ {
+			best, bestQ = i, q
+		}
+	}
+
+	return best
+}
+
+// compressible reports whether content of the type benefits from compression
+func compressible(contentType string) bool {
+	mediaType := baseMediaType(contentType)
+	if incompressibleTypes[mediaType] {
+		return false
+	}
+
+	typ, _ := splitMediaType(mediaType)
+	switch typ {
+	case "image":
+		return mediaType == "image/svg+xml"
+	case "audio", "video":
+		return false
+	}
+	return true
+}
@@ -0,0 +1,98 @@
+package respond
+
+import (
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func largeUsers() []User {
+	users := make([]User, 100)
+	for i := range users {
+		users[i] = User{i, "Billy", "billy@example.com"}
+	}
+	return users
+}
+
+var compressData = []struct {
+	testName string
+
+	acceptEncoding string
+	contentType    string
+	minSize        int
+
+	expectedEncoding string
+}{
+	{"gzip", "gzip", "", 0, "gzip"},
+	{"deflate", "deflate", "", 0, "deflate"},
+	{"gzip preferred on tie", "deflate, gzip", "", 0, "gzip"},
+	{"q-values", "gzip;q=0.5, deflate", "", 0, "deflate"},
+	{"wildcard", "*", "", 0, "gzip"},
+	{"refused", "gzip;q=0, deflate;q=0", "", 0, ""},
+	{"identity only", "identity", "", 0, ""},
+	{"no accept-encoding", "", "", 0, ""},
+	{"below threshold", "gzip", "", 1 << 20, ""},
+	{"already compressed type", "gzip", "image/png", 0, ""},
+}
+
+func TestCompress(t *testing.T) {
+	for _, datum := range compressData {
+		datum := datum
+		t.Run(datum.testName, func(t *testing.T) {
+			t.Parallel()
+
+			req := newRequest(t, "GET")
+			if datum.acceptEncoding != "" {
+				req.Header.Set("Accept-Encoding", datum.acceptEncoding)
+			}
+
+			rr := httptest.NewRecorder()
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				resp := NewResponse(w).WithRequest(r).Compress(datum.minSize)
+				if datum.contentType != "" {
+					resp.Headers["Content-Type"] = datum.contentType
+				}
+				resp.Ok(largeUsers())
+			})
+			handler.ServeHTTP(rr, req)
+
+			if err := validateResponseHeader(rr.Header().Get("Content-Encoding"), datum.expectedEncoding); err != nil {
+				t.Fatal(err)
+			}
+
+			if err := validateResponseHeader(rr.Header().Get("Vary"), "Accept-Encoding"); err != nil {
+				t.Fatal(err)
+			}
+
+			var body io.Reader = rr.Body
+			switch datum.expectedEncoding {
+			case "gzip":
+				zr, err := gzip.NewReader(body)
+				if err != nil {
+					t.Fatal(err)
+				}
+				body = zr
+			case "deflate":
+				zr, err := zlib.NewReader(body)
+				if err != nil {
+					t.Fatal(err)
+				}
+				body = zr
+			}
+
+			decoded, err := ioutil.ReadAll(body)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !strings.HasPrefix(string(decoded), `[{"id":0,"name":"Billy","email":"billy@example.com"}`) {
+				t.Fatalf("Handler returned unexpected body: got %.60s", decoded)
+			}
+		})
+	}
+}
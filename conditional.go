@@ -0,0 +1,151 @@
+package respond
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// ETagMode selects how an ETag is generated from the response body
+type ETagMode int
+
+const (
+	// NoETag disables ETag generation
+	NoETag ETagMode = iota
+	// StrongETag generates strong validators, which change with every byte
+	StrongETag
+	// WeakETag generates weak validators, prefixed with W/
+	WeakETag
+)
+
+// ETag enables generating an ETag from the encoded body of successful
+// responses. GET and HEAD requests whose If-None-Match header matches the
+// ETag are answered with a 304 Not Modified and no body. Conditional
+// requests need the request to be set with WithRequest.
+func (resp *Response) ETag(mode ETagMode) *Response {
+	resp.ETagMode = mode
+	return resp
+}
+
+// LastModified sets the modification time of the resource. Successful
+// responses send it in the Last-Modified header, and GET and HEAD requests
+// whose If-Modified-Since header is not older are answered with a 304 Not
+// Modified. See CheckPreconditions for If-Unmodified-Since. Conditional
+// requests need the request to be set with WithRequest.
+func (resp *Response) LastModified(t time.Time) *Response {
+	resp.Modified = t
+	return resp
+}
+
+// entityTag returns the ETag for the body
+func (resp *Response) entityTag(body []byte) string {
+	sum := sha256.Sum256(body)
+	tag := `"` + hex.EncodeToString(sum[:16]) + `"`
+	if resp.ETagMode == WeakETag {
+		tag = "W/" + tag
+	}
+	return tag
+}
+
+// checkConditions sets the validators of a successful response and
+// evaluates the request's conditional headers against them, returning the
+// status and body to send in place of the given ones. Encoded tells whether
+// the body will be sent with a content coding, in which case the ETag is
+// weak, as the encoded bytes are not those hashed. The same ETag is sent
+// with a 304 as with the full response.
+func (resp *Response) checkConditions(code int, body []byte, encoded bool) (int, []byte) {
+	if code < 200 || code > 299 {
+		return code, body
+	}
+
+	header := resp.Writer.Header()
+
+	etag := ""
+	if resp.ETagMode != NoETag && body != nil {
+		etag = resp.entityTag(body)
+		if (encoded || header.Get("Content-Encoding") != "") && !strings.HasPrefix(etag, "W/") {
+			etag = "W/" + etag
+		}
+		header.Set("ETag", etag)
+	}
+
+	if !resp.Modified.IsZero() {
+		header.Set("Last-Modified", resp.Modified.UTC().Format(http.TimeFormat))
+	}
+
+	if resp.Request == nil || code != http.StatusOK {
+		return code, body
+	}
+
+	switch resp.Request.Method {
+	case http.MethodGet, http.MethodHead:
+	default:
+		return code, body
+	}
+
+	// If-Modified-Since is only evaluated without If-None-Match
+	if inm := resp.Request.Header.Get("If-None-Match"); inm != "" {
+		if etag != "" && etagListMatches(inm, etag) {
+			return resp.notModified()
+		}
+		return code, body
+	}
+
+	if ims, err := http.ParseTime(resp.Request.Header.Get("If-Modified-Since")); err == nil &&
+		!resp.Modified.IsZero() && !resp.Modified.Truncate(time.Second).After(ims) {
+		return resp.notModified()
+	}
+
+	return code, body
+}
+
+// CheckPreconditions evaluates the request's If-Unmodified-Since header
+// against the modification time set with LastModified, and reports whether
+// the request may proceed. When the resource was modified after that time
+// the request is answered with a 412 Precondition Failed and false is
+// returned. Call it before applying a change, with the modification time of
+// the resource as it is before the change. If-Unmodified-Since is ignored
+// when the request has an If-Match header.
+func (resp *Response) CheckPreconditions() bool {
+	if resp.Request == nil || resp.Modified.IsZero() {
+		return true
+	}
+
+	if resp.Request.Header.Get("If-Match") != "" {
+		return true
+	}
+
+	ius, err := http.ParseTime(resp.Request.Header.Get("If-Unmodified-Since"))
+	if err != nil || !resp.Modified.Truncate(time.Second).After(ius) {
+		return true
+	}
+
+	resp.PreconditionFailed(nil)
+	return false
+}
+
+// notModified strips the representation headers of a 304 response
+func (resp *Response) notModified() (int, []byte) {
+	header := resp.Writer.Header()
+	header.Del("Content-Type")
+	header.Del("Content-Length")
+	header.Del("Content-Encoding")
+	return http.StatusNotModified, nil
+}
+
+// etagListMatches reports whether the If-None-Match list contains etag,
+// using weak comparison
+func etagListMatches(list string, etag string) bool {
+	for _, candidate := range strings.Split(list, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" {
+			return true
+		}
+		if strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(etag, "W/") {
+			return true
+		}
+	}
+	return false
+}
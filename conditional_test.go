@@ -0,0 +1,262 @@
+package respond
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func etagFor(t *testing.T, mode ETagMode) string {
+	req := newRequest(t, "GET")
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		NewResponse(w).
+			WithRequest(r).
+			ETag(mode).
+			Ok(&User{1, "Billy", "billy@example.com"})
+	})
+	handler.ServeHTTP(rr, req)
+
+	return rr.Header().Get("ETag")
+}
+
+func TestETag(t *testing.T) {
+	t.Parallel()
+
+	strong := etagFor(t, StrongETag)
+	if !strings.HasPrefix(strong, `"`) || !strings.HasSuffix(strong, `"`) {
+		t.Fatalf("expected a strong ETag, got %v", strong)
+	}
+
+	weak := etagFor(t, WeakETag)
+	if err := validateResponseHeader(weak, "W/"+strong); err != nil {
+		t.Fatal(err)
+	}
+
+	if etag := etagFor(t, NoETag); etag != "" {
+		t.Fatalf("expected no ETag, got %v", etag)
+	}
+}
+
+func TestETagNotModified(t *testing.T) {
+	t.Parallel()
+
+	etag := etagFor(t, StrongETag)
+
+	var ifNoneMatchData = []struct {
+		testName string
+
+		method      string
+		ifNoneMatch string
+
+		expectedStatus int
+	}{
+		{"matching", "GET", etag, http.StatusNotModified},
+		{"matching weak", "GET", "W/" + etag, http.StatusNotModified},
+		{"matching in list", "GET", `"abc", ` + etag, http.StatusNotModified},
+		{"wildcard", "HEAD", "*", http.StatusNotModified},
+		{"not matching", "GET", `"abc"`, http.StatusOK},
+		{"unsafe method", "PUT", etag, http.StatusOK},
+	}
+
+	for _, datum := range ifNoneMatchData {
+		datum := datum
+		t.Run(datum.testName, func(t *testing.T) {
+			req := newRequest(t, datum.method)
+			req.Header.Set("If-None-Match", datum.ifNoneMatch)
+
+			rr := httptest.NewRecorder()
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				NewResponse(w).
+					WithRequest(r).
+					ETag(StrongETag).
+					Ok(&User{1, "Billy", "billy@example.com"})
+			})
+			handler.ServeHTTP(rr, req)
+
+			if err := validateStatusCode(rr.Code, datum.expectedStatus); err != nil {
+				t.Fatal(err)
+			}
+
+			if err := validateResponseHeader(rr.Header().Get("ETag"), etag); err != nil {
+				t.Fatal(err)
+			}
+
+			if datum.expectedStatus == http.StatusNotModified {
+				if err := validateResponseBody(rr.Body.String(), ""); err != nil {
+					t.Fatal(err)
+				}
+				if err := validateResponseHeader(rr.Header().Get("Content-Type"), ""); err != nil {
+					t.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func TestETagCompressed(t *testing.T) {
+	t.Parallel()
+
+	req := newRequest(t, "GET")
+	req.Header.Set("Accept-Encoding", "gzip")
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		NewResponse(w).
+			WithRequest(r).
+			Compress(0).
+			ETag(StrongETag).
+			Ok(largeUsers())
+	})
+	handler.ServeHTTP(rr, req)
+
+	if etag := rr.Header().Get("ETag"); !strings.HasPrefix(etag, `W/"`) {
+		t.Fatalf("expected compressed response to have a weak ETag, got %v", etag)
+	}
+}
+
+func TestETagCompressedNotModified(t *testing.T) {
+	t.Parallel()
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		NewResponse(w).
+			WithRequest(r).
+			Compress(0).
+			ETag(StrongETag).
+			Ok(largeUsers())
+	})
+
+	req := newRequest(t, "GET")
+	req.Header.Set("Accept-Encoding", "gzip")
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	etag := rr.Header().Get("ETag")
+	if !strings.HasPrefix(etag, `W/"`) {
+		t.Fatalf("expected compressed response to have a weak ETag, got %v", etag)
+	}
+
+	req = newRequest(t, "GET")
+	req.Header.Set("Accept-Encoding", "gzip")
+	req.Header.Set("If-None-Match", etag)
+
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	if err := validateStatusCode(rr.Code, http.StatusNotModified); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := validateResponseHeader(rr.Header().Get("ETag"), etag); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := validateResponseHeader(rr.Header().Get("Content-Encoding"), ""); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := validateResponseBody(rr.Body.String(), ""); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLastModified(t *testing.T) {
+	modified := time.Date(2021, time.September, 24, 10, 30, 15, 500, time.UTC)
+
+	var lastModifiedData = []struct {
+		testName string
+
+		method  string
+		header  string
+		value   string
+		respond func(resp *Response) error
+
+		expectedStatus       int
+		expectedBody         string
+		expectedLastModified string
+	}{
+		{"no condition", "GET", "", "",
+			func(resp *Response) error { return resp.Ok(&User{1, "Billy", "billy@example.com"}) },
+			http.StatusOK, `{"id":1,"name":"Billy","email":"billy@example.com"}`, "Fri, 24 Sep 2021 10:30:15 GMT"},
+		{"not modified since", "GET", "If-Modified-Since", "Fri, 24 Sep 2021 10:30:15 GMT",
+			func(resp *Response) error { return resp.Ok(&User{1, "Billy", "billy@example.com"}) },
+			http.StatusNotModified, "", "Fri, 24 Sep 2021 10:30:15 GMT"},
+		{"modified since", "GET", "If-Modified-Since", "Fri, 24 Sep 2021 10:30:14 GMT",
+			func(resp *Response) error { return resp.Ok(&User{1, "Billy", "billy@example.com"}) },
+			http.StatusOK, `{"id":1,"name":"Billy","email":"billy@example.com"}`, "Fri, 24 Sep 2021 10:30:15 GMT"},
+		{"invalid date ignored", "GET", "If-Modified-Since", "yesterday",
+			func(resp *Response) error { return resp.Ok(&User{1, "Billy", "billy@example.com"}) },
+			http.StatusOK, `{"id":1,"name":"Billy","email":"billy@example.com"}`, "Fri, 24 Sep 2021 10:30:15 GMT"},
+		{"unmodified since", "PUT", "If-Unmodified-Since", "Fri, 24 Sep 2021 10:30:15 GMT",
+			func(resp *Response) error {
+				if !resp.CheckPreconditions() {
+					return nil
+				}
+				return resp.NoContent()
+			},
+			http.StatusNoContent, "", "Fri, 24 Sep 2021 10:30:15 GMT"},
+		{"modified after precondition", "PUT", "If-Unmodified-Since", "Fri, 24 Sep 2021 10:30:14 GMT",
+			func(resp *Response) error {
+				if !resp.CheckPreconditions() {
+					return nil
+				}
+				return resp.NoContent()
+			},
+			http.StatusPreconditionFailed, `{"status":412,"message":"Precondition Failed"}`, ""},
+		{"if-match takes precedence", "PUT", "If-Match", `"abc"`,
+			func(resp *Response) error {
+				if !resp.CheckPreconditions() {
+					return nil
+				}
+				return resp.NoContent()
+			},
+			http.StatusNoContent, "", "Fri, 24 Sep 2021 10:30:15 GMT"},
+		{"response after update", "PUT", "If-Unmodified-Since", "Fri, 24 Sep 2021 10:30:15 GMT",
+			func(resp *Response) error {
+				if !resp.CheckPreconditions() {
+					return nil
+				}
+				return resp.LastModified(modified.Add(time.Hour)).Ok(&User{1, "Billy", "billy@example.com"})
+			},
+			http.StatusOK, `{"id":1,"name":"Billy","email":"billy@example.com"}`, "Fri, 24 Sep 2021 11:30:15 GMT"},
+	}
+
+	for _, datum := range lastModifiedData {
+		datum := datum
+		t.Run(datum.testName, func(t *testing.T) {
+			t.Parallel()
+
+			req := newRequest(t, datum.method)
+			if datum.header != "" {
+				req.Header.Set(datum.header, datum.value)
+			}
+
+			rr := httptest.NewRecorder()
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				datum.respond(NewResponse(w).
+					WithRequest(r).
+					DefaultMessage().
+					LastModified(modified))
+			})
+			handler.ServeHTTP(rr, req)
+
+			if err := validateStatusCode(rr.Code, datum.expectedStatus); err != nil {
+				t.Fatal(err)
+			}
+
+			if err := validateResponseBody(rr.Body.String(), datum.expectedBody); err != nil {
+				t.Fatal(err)
+			}
+
+			if datum.expectedStatus != http.StatusPreconditionFailed {
+				if err := validateResponseHeader(rr.Header().Get("Last-Modified"), datum.expectedLastModified); err != nil {
+					t.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package respond
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+)
+
+// ErrInvalidCursor is returned when a cursor is malformed or its signature
+// does not match
+var ErrInvalidCursor = errors.New("respond: invalid cursor")
+
+// CursorCodec encodes pagination state into opaque cursors, signed with
+// HMAC-SHA256 so that clients cannot forge or alter them
+type CursorCodec struct {
+	key []byte
+}
+
+// NewCursorCodec creates a cursor codec signing with the given secret key
+func NewCursorCodec(key []byte) *CursorCodec {
+	if len(key) == 0 {
+		panic("respond: cursor key must not be empty")
+	}
+	return &CursorCodec{key: append([]byte(nil), key...)}
+}
+
+// Encode returns the cursor for the state, which is encoded as JSON
+func (c *CursorCodec) Encode(state interface{}) (string, error) {
+	payload, err := json.Marshal(state)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(append(payload, c.sign(payload)...)), nil
+}
+
+// Decode verifies the cursor and decodes its state into the value pointed
+// to by state
+func (c *CursorCodec) Decode(cursor string, state interface{}) error {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil || len(raw) < sha256.Size {
+		return ErrInvalidCursor
+	}
+
+	payload, mac := raw[:len(raw)-sha256.Size], raw[len(raw)-sha256.Size:]
+	if !hmac.Equal(mac, c.sign(payload)) {
+		return ErrInvalidCursor
+	}
+
+	if err := json.Unmarshal(payload, state); err != nil {
+		return ErrInvalidCursor
+	}
+	return nil
+}
+
+// FromRequest decodes the cursor in the request's cursor query parameter
+// into state, returning the cursor. An empty cursor is returned, and state
+// left untouched, when the request has none.
+func (c *CursorCodec) FromRequest(r *http.Request, state interface{}) (string, error) {
+	cursor := r.URL.Query().Get(cursorParam)
+	if cursor == "" {
+		return "", nil
+	}
+	if err := c.Decode(cursor, state); err != nil {
+		return "", err
+	}
+	return cursor, nil
+}
+
+// Page creates a cursor page of up to limit items, identified by the
+// current cursor, with cursors encoded from the states of the next and
+// previous pages. A nil state means there is no such page.
+func (c *CursorCodec) Page(limit int, current string, next, prev interface{}) (*Page, error) {
+	var nextCursor, prevCursor string
+	var err error
+
+	if next != nil {
+		if nextCursor, err = c.Encode(next); err != nil {
+			return nil, err
+		}
+	}
+	if prev != nil {
+		if prevCursor, err = c.Encode(prev); err != nil {
+			return nil, err
+		}
+	}
+
+	return CursorPage(limit, current, nextCursor, prevCursor), nil
+}
+
+func (c *CursorCodec) sign(payload []byte) []byte {
+	mac := hmac.New(sha256.New, c.key)
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
@@ -0,0 +1,102 @@
+package respond
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type userCursor struct {
+	AfterID int `json:"after_id"`
+}
+
+func TestCursorCodec(t *testing.T) {
+	t.Parallel()
+
+	codec := NewCursorCodec([]byte("secret"))
+
+	cursor, err := codec.Encode(userCursor{42})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if strings.ContainsAny(cursor, "+/=") {
+		t.Fatalf("expected a base64url cursor without padding, got %v", cursor)
+	}
+
+	var state userCursor
+	if err := codec.Decode(cursor, &state); err != nil {
+		t.Fatal(err)
+	}
+
+	if state.AfterID != 42 {
+		t.Fatalf("decoded unexpected state: got %v wanted 42", state.AfterID)
+	}
+}
+
+func TestCursorCodecInvalid(t *testing.T) {
+	t.Parallel()
+
+	codec := NewCursorCodec([]byte("secret"))
+
+	cursor, err := NewCursorCodec([]byte("other")).Encode(userCursor{42})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, invalid := range []string{cursor, "not base64!", "c2hvcnQ", cursor[1:]} {
+		var state userCursor
+		if err := codec.Decode(invalid, &state); err != ErrInvalidCursor {
+			t.Fatalf("expected ErrInvalidCursor for %q, got %v", invalid, err)
+		}
+	}
+}
+
+func TestCursorPage(t *testing.T) {
+	t.Parallel()
+
+	codec := NewCursorCodec([]byte("secret"))
+	current, _ := codec.Encode(userCursor{10})
+	next, _ := codec.Encode(userCursor{20})
+
+	req, err := http.NewRequest("GET", "/users?cursor="+current, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var state userCursor
+		cursor, err := codec.FromRequest(r, &state)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if state.AfterID != 10 {
+			t.Errorf("decoded unexpected state: got %v wanted 10", state.AfterID)
+		}
+
+		page, err := codec.Page(10, cursor, userCursor{20}, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		NewResponse(w).
+			WithRequest(r).
+			Paginate(page.WithMeta()).
+			Ok([]User{})
+	})
+	handler.ServeHTTP(rr, req)
+
+	expectedLink := `</users?limit=10>; rel="first", </users?cursor=` + next + `&limit=10>; rel="next"`
+	if err := validateResponseHeader(rr.Header().Get("Link"), expectedLink); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `{"data":[],"meta":{"pagination":{"limit":10,"cursor":"` + current +
+		`","next_cursor":"` + next + `"}}}`
+	if err := validateResponseBody(rr.Body.String(), expected); err != nil {
+		t.Fatal(err)
+	}
+}
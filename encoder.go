@@ -0,0 +1,151 @@
+package respond
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"reflect"
+)
+
+// Encoder encodes response bodies for a single content type
+type Encoder interface {
+	// ContentType returns the value sent in the Content-Type header
+	ContentType() string
+	// Encode returns the encoded form of v
+	Encode(v interface{}) ([]byte, error)
+}
+
+// EncodeFunc encodes v into a response body
+type EncodeFunc func(v interface{}) ([]byte, error)
+
+type funcEncoder struct {
+	contentType string
+	encode      EncodeFunc
+}
+
+func (e funcEncoder) ContentType() string                  { return e.contentType }
+func (e funcEncoder) Encode(v interface{}) ([]byte, error) { return e.encode(v) }
+
+// NewEncoder creates an Encoder from a content type and an encode function
+func NewEncoder(contentType string, fn EncodeFunc) Encoder {
+	return funcEncoder{contentType, fn}
+}
+
+// JSONEncoder encodes response bodies as JSON
+type JSONEncoder struct{}
+
+// ContentType returns the JSON content type
+func (JSONEncoder) ContentType() string {
+	return "application/json; charset=utf-8"
+}
+
+// Encode returns the JSON encoding of v
+func (JSONEncoder) Encode(v interface{}) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+// EncodeIndent returns the indented JSON encoding of v
+func (JSONEncoder) EncodeIndent(v interface{}, indent string) ([]byte, error) {
+	return json.MarshalIndent(v, "", indent)
+}
+
+// XMLEncoder encodes response bodies as XML documents. Slices and arrays
+// are sent as a response root element holding an element per item, and maps
+// as a response root element holding an element per key, in key order. Maps
+// nested in structs cannot be encoded.
+type XMLEncoder struct{}
+
+// ContentType returns the XML content type
+func (XMLEncoder) ContentType() string {
+	return "application/xml; charset=utf-8"
+}
+
+// Encode returns the XML encoding of v, preceded by the XML header
+func (XMLEncoder) Encode(v interface{}) ([]byte, error) {
+	body, err := xml.Marshal(xmlRoot(v))
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), body...), nil
+}
+
+// EncodeIndent returns the indented XML encoding of v, preceded by the XML
+// header
+func (XMLEncoder) EncodeIndent(v interface{}, indent string) ([]byte, error) {
+	body, err := xml.MarshalIndent(xmlRoot(v), "", indent)
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), body...), nil
+}
+
+// xmlRoot returns the value encoding v as a single root element. Slices,
+// arrays and maps, which would otherwise encode to a root element per item
+// or not at all, are wrapped in a response element, unless they encode
+// themselves.
+func xmlRoot(v interface{}) interface{} {
+	if _, ok := v.(xml.Marshaler); ok {
+		return v
+	}
+
+	rv := indirect(reflect.ValueOf(v))
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		if rv.Type().Elem().Kind() == reflect.Uint8 {
+			return v
+		}
+		return xmlList{rv}
+	case reflect.Map:
+		return xmlMembers{xmlMap(v).(Meta)}
+	}
+	return v
+}
+
+// xmlMap returns a map as a Meta, whose entries encode as elements, as
+// encoding/xml cannot encode maps. Other values are returned as they are.
+func xmlMap(v interface{}) interface{} {
+	if _, ok := v.(xml.Marshaler); ok {
+		return v
+	}
+
+	rv := indirect(reflect.ValueOf(v))
+	if rv.Kind() != reflect.Map {
+		return v
+	}
+
+	members := make(Meta, rv.Len())
+	iter := rv.MapRange()
+	for iter.Next() {
+		members[fmt.Sprint(iter.Key().Interface())] = iter.Value().Interface()
+	}
+	return members
+}
+
+// xmlList encodes the items of a slice or array in a response element
+type xmlList struct {
+	items reflect.Value
+}
+
+func (l xmlList) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	start = xml.StartElement{Name: xml.Name{Local: "response"}}
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+
+	for i := 0; i < l.items.Len(); i++ {
+		if err := e.Encode(l.items.Index(i).Interface()); err != nil {
+			return err
+		}
+	}
+
+	return e.EncodeToken(start.End())
+}
+
+// xmlMembers encodes the members of a map in a response element
+type xmlMembers struct {
+	members Meta
+}
+
+func (m xmlMembers) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return e.EncodeElement(m.members, xml.StartElement{Name: xml.Name{Local: "response"}})
+}
@@ -0,0 +1,105 @@
+package respond
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestXMLDefaultMessage(t *testing.T) {
+	t.Parallel()
+
+	req := newRequest(t, "GET")
+	req.Header.Set("Accept", "application/xml")
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		NewResponse(w).
+			WithRequest(r).
+			AddEncoder(XMLEncoder{}).
+			DefaultMessage().
+			NotFound(nil)
+	})
+	handler.ServeHTTP(rr, req)
+
+	if err := validateStatusCode(rr.Code, http.StatusNotFound); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := validateResponseHeader(rr.Header().Get("Content-Type"), "application/xml; charset=utf-8"); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `<?xml version="1.0" encoding="UTF-8"?>` + "\n" +
+		`<response><status>404</status><message>Not Found</message></response>`
+	if err := validateResponseBody(rr.Body.String(), expected); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestWithEncoder(t *testing.T) {
+	t.Parallel()
+
+	req := newRequest(t, "GET")
+	req.Header.Set("Accept", "application/json")
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		NewResponse(w).
+			WithRequest(r).
+			WithEncoder(XMLEncoder{}).
+			Ok(&User{1, "Billy", "billy@example.com"})
+	})
+	handler.ServeHTTP(rr, req)
+
+	if err := validateResponseHeader(rr.Header().Get("Content-Type"), "application/xml; charset=utf-8"); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `<?xml version="1.0" encoding="UTF-8"?>` + "\n" +
+		`<User><ID>1</ID><Name>Billy</Name><Email>billy@example.com</Email></User>`
+	if err := validateResponseBody(rr.Body.String(), expected); err != nil {
+		t.Fatal(err)
+	}
+}
+
+var xmlCollectionData = []struct {
+	testName string
+
+	body interface{}
+
+	expectedBody string
+}{
+	{"slice", []User{{1, "Billy", "billy@example.com"}, {2, "Jill", "jill@example.com"}},
+		`<response><User><ID>1</ID><Name>Billy</Name><Email>billy@example.com</Email></User>` +
+			`<User><ID>2</ID><Name>Jill</Name><Email>jill@example.com</Email></User></response>`},
+	{"pointer to slice", &[]int{1, 2}, `<response><int>1</int><int>2</int></response>`},
+	{"empty slice", []User{}, `<response></response>`},
+	{"map", map[string]int{"b": 2, "a": 1}, `<response><a>1</a><b>2</b></response>`},
+}
+
+func TestXMLCollections(t *testing.T) {
+	for _, datum := range xmlCollectionData {
+		datum := datum
+		t.Run(datum.testName, func(t *testing.T) {
+			t.Parallel()
+
+			req := newRequest(t, "GET")
+
+			rr := httptest.NewRecorder()
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				NewResponse(w).WithEncoder(XMLEncoder{}).Ok(datum.body)
+			})
+			handler.ServeHTTP(rr, req)
+
+			if err := validateStatusCode(rr.Code, http.StatusOK); err != nil {
+				t.Fatal(err)
+			}
+
+			expected := `<?xml version="1.0" encoding="UTF-8"?>` + "\n" + datum.expectedBody
+			if err := validateResponseBody(rr.Body.String(), expected); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
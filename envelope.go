@@ -0,0 +1,207 @@
+package respond
+
+import (
+	"bytes"
+	"encoding/xml"
+	"net/http"
+	"reflect"
+	"sort"
+	"time"
+)
+
+// Formatter shapes the body of a response before it is encoded, e.g. to
+// wrap it in an envelope. Format is given the status code and the body, which
+// is never nil.
+type Formatter interface {
+	Format(resp *Response, code int, v interface{}) interface{}
+}
+
+// DefaultFormatter is the formatter of responses that have not been given
+// one. It is nil, sending bodies as they are, unless set.
+var DefaultFormatter Formatter
+
+// Format sets the formatter of the response, overriding DefaultFormatter
+func (resp *Response) Format(f Formatter) *Response {
+	resp.Formatter = f
+	return resp
+}
+
+// Meta adds a member to the meta block of formatted responses
+func (resp *Response) Meta(key string, value interface{}) *Response {
+	if resp.Metadata == nil {
+		resp.Metadata = make(Meta)
+	}
+	resp.Metadata[key] = value
+	return resp
+}
+
+// format applies the response's formatter to the body. Without a formatter
+// only the page meta is added. HAL applies to JSON encodings only, other
+// encodings are sent the value of a representation.
+func (resp *Response) format(enc Encoder, code int, v interface{}) interface{} {
+	if v == nil {
+		return nil
+	}
+
+	f := resp.Formatter
+	if f == nil {
+		f = DefaultFormatter
+	}
+	switch f.(type) {
+	case HAL, *HAL:
+		if !isJSONMediaType(enc.ContentType()) {
+			f = nil
+			v = representationValue(v)
+		}
+	}
+	if f == nil {
+		return resp.pageBody(code, v)
+	}
+	return f.Format(resp, code, v)
+}
+
+// Meta holds the members of a meta block
+type Meta map[string]interface{}
+
+// MarshalXML encodes the members as elements, in key order, with map
+// values encoded the same way
+func (m Meta) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if err := e.EncodeElement(xmlMap(m[key]), xml.StartElement{Name: xml.Name{Local: key}}); err != nil {
+			return err
+		}
+	}
+
+	return e.EncodeToken(start.End())
+}
+
+// Envelope is a Formatter wrapping successful bodies under data and error
+// bodies under errors, alongside a meta block holding the response's Meta
+// members, the request ID, a timestamp and the pagination, e.g.
+//
+//	{"data": ..., "meta": {"request_id": "..."}}
+//	{"errors": [...], "meta": {"request_id": "..."}}
+type Envelope struct {
+	// RequestIDHeader is the request header copied to the request_id meta
+	// member. When empty X-Request-ID is used.
+	RequestIDHeader string
+	// Timestamp adds the time of the response as the timestamp meta member
+	Timestamp bool
+}
+
+// EnvelopeBody is the body of an enveloped response
+type EnvelopeBody struct {
+	Data   interface{} `json:"data,omitempty"`
+	Errors interface{} `json:"errors,omitempty"`
+	Meta   Meta        `json:"meta,omitempty"`
+}
+
+// Format wraps the body in an EnvelopeBody. Error bodies that are not
+// already a slice or array are wrapped in one.
+func (e *Envelope) Format(resp *Response, code int, v interface{}) interface{} {
+	body := EnvelopeBody{}
+
+	if code >= http.StatusBadRequest {
+		body.Errors = errorList(v)
+	} else {
+		body.Data = v
+	}
+
+	meta := Meta{}
+	for key, value := range resp.Metadata {
+		meta[key] = value
+	}
+
+	if resp.Request != nil {
+		header := e.RequestIDHeader
+		if header == "" {
+			header = "X-Request-ID"
+		}
+		if id := resp.Request.Header.Get(header); id != "" {
+			meta["request_id"] = id
+		}
+	}
+
+	if e.Timestamp {
+		meta["timestamp"] = time.Now().UTC().Format(time.RFC3339)
+	}
+
+	if resp.Page != nil && code >= 200 && code <= 299 {
+		meta["pagination"] = resp.Page.meta()
+	}
+
+	if len(meta) > 0 {
+		body.Meta = meta
+	}
+	return body
+}
+
+// MarshalXML encodes the envelope as a response element holding data,
+// errors and meta elements
+func (b EnvelopeBody) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	start = xml.StartElement{Name: xml.Name{Local: "response"}}
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+
+	if b.Data != nil {
+		if err := encodeXMLWrapped(e, "data", b.Data); err != nil {
+			return err
+		}
+	}
+	if b.Errors != nil {
+		if err := encodeXMLWrapped(e, "errors", b.Errors); err != nil {
+			return err
+		}
+	}
+	if len(b.Meta) > 0 {
+		if err := e.EncodeElement(b.Meta, xml.StartElement{Name: xml.Name{Local: "meta"}}); err != nil {
+			return err
+		}
+	}
+
+	return e.EncodeToken(start.End())
+}
+
+// encodeXMLWrapped encodes v inside an element of the given name
+func encodeXMLWrapped(e *xml.Encoder, name string, v interface{}) error {
+	start := xml.StartElement{Name: xml.Name{Local: name}}
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+	if err := e.Encode(v); err != nil {
+		return err
+	}
+	return e.EncodeToken(start.End())
+}
+
+// errorList returns v when it is a collection, or else a slice holding v
+func errorList(v interface{}) interface{} {
+	if isCollection(v) {
+		return v
+	}
+	return []interface{}{v}
+}
+
+// isCollection reports whether v is a slice or array, or a pruned body
+// holding a JSON array
+func isCollection(v interface{}) bool {
+	if b, ok := v.(prunedBody); ok {
+		return bytes.HasPrefix(bytes.TrimSpace(b), []byte("["))
+	}
+	switch reflect.ValueOf(v).Kind() {
+	case reflect.Slice, reflect.Array:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,120 @@
+package respond
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+var envelopeData = []struct {
+	testName string
+
+	respond func(resp *Response) error
+
+	expectedBody string
+}{
+	{"success",
+		func(resp *Response) error { return resp.Ok(&User{1, "Billy", "billy@example.com"}) },
+		`{"data":{"id":1,"name":"Billy","email":"billy@example.com"},"meta":{"request_id":"abc123"}}`},
+	{"error",
+		func(resp *Response) error { return resp.NotFound(&Error{404, "Not found"}) },
+		`{"errors":[{"code":404,"message":"Not found"}],"meta":{"request_id":"abc123"}}`},
+	{"error list",
+		func(resp *Response) error {
+			return resp.BadRequest([]Error{{400, "Name is required"}, {400, "Email is invalid"}})
+		},
+		`{"errors":[{"code":400,"message":"Name is required"},{"code":400,"message":"Email is invalid"}],` +
+			`"meta":{"request_id":"abc123"}}`},
+	{"default message",
+		func(resp *Response) error { return resp.DefaultMessage().Unauthorized(nil) },
+		`{"errors":[{"status":401,"message":"Unauthorized"}],"meta":{"request_id":"abc123"}}`},
+	{"no body",
+		func(resp *Response) error { return resp.NoContent() },
+		``},
+	{"meta and pagination",
+		func(resp *Response) error {
+			return resp.Meta("version", "v2").Paginate(OffsetPage(0, 10, 1)).Ok([]int{1})
+		},
+		`{"data":[1],"meta":{"pagination":{"limit":10,"offset":0,"total":1},"request_id":"abc123","version":"v2"}}`},
+}
+
+func TestEnvelope(t *testing.T) {
+	for _, datum := range envelopeData {
+		datum := datum
+		t.Run(datum.testName, func(t *testing.T) {
+			t.Parallel()
+
+			req := newRequest(t, "GET")
+			req.Header.Set("X-Request-ID", "abc123")
+
+			rr := httptest.NewRecorder()
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				datum.respond(NewResponse(w).WithRequest(r).Format(&Envelope{}))
+			})
+			handler.ServeHTTP(rr, req)
+
+			if err := validateResponseBody(rr.Body.String(), datum.expectedBody); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestEnvelopeTimestamp(t *testing.T) {
+	t.Parallel()
+
+	req := newRequest(t, "GET")
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		NewResponse(w).Format(&Envelope{Timestamp: true}).Ok([]int{})
+	})
+	handler.ServeHTTP(rr, req)
+
+	if !strings.HasPrefix(rr.Body.String(), `{"data":[],"meta":{"timestamp":"`) {
+		t.Fatalf("Handler returned unexpected body: got %v", rr.Body.String())
+	}
+}
+
+func TestEnvelopeXML(t *testing.T) {
+	t.Parallel()
+
+	req := newRequest(t, "GET")
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		NewResponse(w).
+			WithEncoder(XMLEncoder{}).
+			Format(&Envelope{}).
+			Meta("version", "v2").
+			DefaultMessage().
+			NotFound(nil)
+	})
+	handler.ServeHTTP(rr, req)
+
+	expected := `<?xml version="1.0" encoding="UTF-8"?>` + "\n" +
+		`<response><errors><response><status>404</status><message>Not Found</message></response></errors>` +
+		`<meta><version>v2</version></meta></response>`
+	if err := validateResponseBody(rr.Body.String(), expected); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestDefaultFormatter is not parallel as it changes the package default
+func TestDefaultFormatter(t *testing.T) {
+	DefaultFormatter = &Envelope{}
+	defer func() { DefaultFormatter = nil }()
+
+	req := newRequest(t, "GET")
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		NewResponse(w).Ok([]int{1})
+	})
+	handler.ServeHTTP(rr, req)
+
+	if err := validateResponseBody(rr.Body.String(), `{"data":[1]}`); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -1,93 +1,200 @@
+// Code generated by gen_status.go. DO NOT EDIT.
+
 package respond
 
 import "net/http"
 
 // BadRequest returns a 400 Bad Request JSON response
-func (resp *Response) BadRequest(v interface{}) {
-	resp.writeResponse(http.StatusBadRequest, v)
+func (resp *Response) BadRequest(v interface{}) error {
+	return resp.writeResponse(http.StatusBadRequest, v)
 }
 
 // Unauthorized returns a 401 Unauthorized JSON response
-func (resp *Response) Unauthorized(v interface{}) {
-	resp.writeResponse(http.StatusUnauthorized, v)
+func (resp *Response) Unauthorized(v interface{}) error {
+	return resp.writeResponse(http.StatusUnauthorized, v)
+}
+
+// PaymentRequired returns a 402 Payment Required JSON response
+func (resp *Response) PaymentRequired(v interface{}) error {
+	return resp.writeResponse(http.StatusPaymentRequired, v)
 }
 
 // Forbidden returns a 403 Forbidden JSON response
-func (resp *Response) Forbidden(v interface{}) {
-	resp.writeResponse(http.StatusForbidden, v)
+func (resp *Response) Forbidden(v interface{}) error {
+	return resp.writeResponse(http.StatusForbidden, v)
 }
 
 // NotFound returns a 404 Not Found JSON response
-func (resp *Response) NotFound(v interface{}) {
-	resp.writeResponse(http.StatusNotFound, v)
+func (resp *Response) NotFound(v interface{}) error {
+	return resp.writeResponse(http.StatusNotFound, v)
 }
 
 // MethodNotAllowed returns a 405 Method Not Allowed JSON response
-func (resp *Response) MethodNotAllowed(v interface{}) {
-	resp.writeResponse(http.StatusMethodNotAllowed, v)
+func (resp *Response) MethodNotAllowed(v interface{}) error {
+	return resp.writeResponse(http.StatusMethodNotAllowed, v)
 }
 
 // NotAcceptable returns a 406 Not Acceptable JSON response
-func (resp *Response) NotAcceptable(v interface{}) {
-	resp.writeResponse(http.StatusNotAcceptable, v)
+func (resp *Response) NotAcceptable(v interface{}) error {
+	return resp.writeResponse(http.StatusNotAcceptable, v)
+}
+
+// ProxyAuthRequired returns a 407 Proxy Authentication Required JSON response
+func (resp *Response) ProxyAuthRequired(v interface{}) error {
+	return resp.writeResponse(http.StatusProxyAuthRequired, v)
+}
+
+// RequestTimeout returns a 408 Request Timeout JSON response
+func (resp *Response) RequestTimeout(v interface{}) error {
+	return resp.writeResponse(http.StatusRequestTimeout, v)
 }
 
 // Conflict returns a 409 Conflict JSON response
-func (resp *Response) Conflict(v interface{}) {
-	resp.writeResponse(http.StatusConflict, v)
+func (resp *Response) Conflict(v interface{}) error {
+	return resp.writeResponse(http.StatusConflict, v)
 }
 
 // Gone returns a 410 Gone JSON response
-func (resp *Response) Gone(v interface{}) {
-	resp.writeResponse(http.StatusGone, v)
+func (resp *Response) Gone(v interface{}) error {
+	return resp.writeResponse(http.StatusGone, v)
 }
 
 // LengthRequired returns a 411 Length Required JSON response
-func (resp *Response) LengthRequired(v interface{}) {
-	resp.writeResponse(http.StatusLengthRequired, v)
+func (resp *Response) LengthRequired(v interface{}) error {
+	return resp.writeResponse(http.StatusLengthRequired, v)
 }
 
 // PreconditionFailed returns a 412 Precondition Failed JSON response
-func (resp *Response) PreconditionFailed(v interface{}) {
-	resp.writeResponse(http.StatusPreconditionFailed, v)
+func (resp *Response) PreconditionFailed(v interface{}) error {
+	return resp.writeResponse(http.StatusPreconditionFailed, v)
 }
 
 // RequestEntityTooLarge returns a 413 Request Entity Too Large JSON response
-func (resp *Response) RequestEntityTooLarge(v interface{}) {
-	resp.writeResponse(http.StatusRequestEntityTooLarge, v)
+func (resp *Response) RequestEntityTooLarge(v interface{}) error {
+	return resp.writeResponse(http.StatusRequestEntityTooLarge, v)
+}
+
+// RequestURITooLong returns a 414 Request URI Too Long JSON response
+func (resp *Response) RequestURITooLong(v interface{}) error {
+	return resp.writeResponse(http.StatusRequestURITooLong, v)
 }
 
 // UnsupportedMediaType returns a 415 Unsupported Media Type JSON response
-func (resp *Response) UnsupportedMediaType(v interface{}) {
-	resp.writeResponse(http.StatusUnsupportedMediaType, v)
+func (resp *Response) UnsupportedMediaType(v interface{}) error {
+	return resp.writeResponse(http.StatusUnsupportedMediaType, v)
+}
+
+// RequestedRangeNotSatisfiable returns a 416 Requested Range Not Satisfiable JSON response
+func (resp *Response) RequestedRangeNotSatisfiable(v interface{}) error {
+	return resp.writeResponse(http.StatusRequestedRangeNotSatisfiable, v)
+}
+
+// ExpectationFailed returns a 417 Expectation Failed JSON response
+func (resp *Response) ExpectationFailed(v interface{}) error {
+	return resp.writeResponse(http.StatusExpectationFailed, v)
+}
+
+// MisdirectedRequest returns a 421 Misdirected Request JSON response
+func (resp *Response) MisdirectedRequest(v interface{}) error {
+	return resp.writeResponse(http.StatusMisdirectedRequest, v)
 }
 
 // UnprocessableEntity returns a 422 Unprocessable Entity JSON response
-func (resp *Response) UnprocessableEntity(v interface{}) {
-	resp.writeResponse(http.StatusUnprocessableEntity, v)
+func (resp *Response) UnprocessableEntity(v interface{}) error {
+	return resp.writeResponse(http.StatusUnprocessableEntity, v)
+}
+
+// Locked returns a 423 Locked JSON response
+func (resp *Response) Locked(v interface{}) error {
+	return resp.writeResponse(http.StatusLocked, v)
+}
+
+// FailedDependency returns a 424 Failed Dependency JSON response
+func (resp *Response) FailedDependency(v interface{}) error {
+	return resp.writeResponse(http.StatusFailedDependency, v)
+}
+
+// TooEarly returns a 425 Too Early JSON response
+func (resp *Response) TooEarly(v interface{}) error {
+	return resp.writeResponse(http.StatusTooEarly, v)
+}
+
+// UpgradeRequired returns a 426 Upgrade Required JSON response
+func (resp *Response) UpgradeRequired(v interface{}) error {
+	return resp.writeResponse(http.StatusUpgradeRequired, v)
+}
+
+// PreconditionRequired returns a 428 Precondition Required JSON response
+func (resp *Response) PreconditionRequired(v interface{}) error {
+	return resp.writeResponse(http.StatusPreconditionRequired, v)
+}
+
+// TooManyRequests returns a 429 Too Many Requests JSON response
+func (resp *Response) TooManyRequests(v interface{}) error {
+	return resp.writeResponse(http.StatusTooManyRequests, v)
+}
+
+// RequestHeaderFieldsTooLarge returns a 431 Request Header Fields Too Large JSON response
+func (resp *Response) RequestHeaderFieldsTooLarge(v interface{}) error {
+	return resp.writeResponse(http.StatusRequestHeaderFieldsTooLarge, v)
+}
+
+// UnavailableForLegalReasons returns a 451 Unavailable For Legal Reasons JSON response
+func (resp *Response) UnavailableForLegalReasons(v interface{}) error {
+	return resp.writeResponse(http.StatusUnavailableForLegalReasons, v)
 }
 
 // InternalServerError returns a 500 Internal Server Error JSON response
-func (resp *Response) InternalServerError(v interface{}) {
-	resp.writeResponse(http.StatusInternalServerError, v)
+func (resp *Response) InternalServerError(v interface{}) error {
+	return resp.writeResponse(http.StatusInternalServerError, v)
 }
 
 // NotImplemented returns a 501 Not Implemented JSON response
-func (resp *Response) NotImplemented(v interface{}) {
-	resp.writeResponse(http.StatusNotImplemented, v)
+func (resp *Response) NotImplemented(v interface{}) error {
+	return resp.writeResponse(http.StatusNotImplemented, v)
 }
 
 // BadGateway returns a 502 Bad Gateway JSON response
-func (resp *Response) BadGateway(v interface{}) {
-	resp.writeResponse(http.StatusBadGateway, v)
+func (resp *Response) BadGateway(v interface{}) error {
+	return resp.writeResponse(http.StatusBadGateway, v)
 }
 
 // ServiceUnavailable returns a 503 Service Unavailable JSON response
-func (resp *Response) ServiceUnavailable(v interface{}) {
-	resp.writeResponse(http.StatusServiceUnavailable, v)
+func (resp *Response) ServiceUnavailable(v interface{}) error {
+	return resp.writeResponse(http.StatusServiceUnavailable, v)
 }
 
 // GatewayTimeout returns a 504 Gateway Timeout JSON response
-func (resp *Response) GatewayTimeout(v interface{}) {
-	resp.writeResponse(http.StatusGatewayTimeout, v)
+func (resp *Response) GatewayTimeout(v interface{}) error {
+	return resp.writeResponse(http.StatusGatewayTimeout, v)
+}
+
+// HTTPVersionNotSupported returns a 505 HTTP Version Not Supported JSON response
+func (resp *Response) HTTPVersionNotSupported(v interface{}) error {
+	return resp.writeResponse(http.StatusHTTPVersionNotSupported, v)
+}
+
+// VariantAlsoNegotiates returns a 506 Variant Also Negotiates JSON response
+func (resp *Response) VariantAlsoNegotiates(v interface{}) error {
+	return resp.writeResponse(http.StatusVariantAlsoNegotiates, v)
+}
+
+// InsufficientStorage returns a 507 Insufficient Storage JSON response
+func (resp *Response) InsufficientStorage(v interface{}) error {
+	return resp.writeResponse(http.StatusInsufficientStorage, v)
+}
+
+// LoopDetected returns a 508 Loop Detected JSON response
+func (resp *Response) LoopDetected(v interface{}) error {
+	return resp.writeResponse(http.StatusLoopDetected, v)
+}
+
+// NotExtended returns a 510 Not Extended JSON response
+func (resp *Response) NotExtended(v interface{}) error {
+	return resp.writeResponse(http.StatusNotExtended, v)
+}
+
+// NetworkAuthenticationRequired returns a 511 Network Authentication Required JSON response
+func (resp *Response) NetworkAuthenticationRequired(v interface{}) error {
+	return resp.writeResponse(http.StatusNetworkAuthenticationRequired, v)
 }
@@ -348,7 +348,7 @@ var testData = []struct {
 	inputHttpCode int
 	inputJsonData string
 
-	methodUnderTest func(r *Response, v interface{})
+	methodUnderTest func(r *Response, v interface{}) error
 
 	expectedStatus int
 	expectedBody   string
@@ -0,0 +1,184 @@
+package respond
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+)
+
+// fieldsParam is the query parameter listing the fields to send
+const fieldsParam = "fields"
+
+// SparseFieldsets sends only the fields listed in the request's fields query
+// parameter, e.g. ?fields=id,name,address.city, of successful JSON bodies.
+// Nested fields are given as dotted paths, and apply to each element of
+// arrays. Fields are named as they are encoded, so json struct tags are
+// respected. Formatters such as Envelope and HAL are applied to the pruned
+// body, and JSON:API documents and bodies with their own media type are sent
+// whole.
+func (resp *Response) SparseFieldsets() *Response {
+	resp.SparseFields = true
+	return resp
+}
+
+// fieldSet is a tree of the selected fields. Fields that were selected
+// whole, rather than only some of their members, hold the wholeField key.
+type fieldSet map[string]fieldSet
+
+// wholeField marks a field selected whole
+const wholeField = ""
+
+// parseFields builds the field set from a comma separated list of dotted
+// paths
+func parseFields(list string) fieldSet {
+	set := make(fieldSet)
+	for _, path := range strings.Split(list, ",") {
+		node, selected := set, false
+		for _, name := range strings.Split(strings.TrimSpace(path), ".") {
+			if name == "" {
+				continue
+			}
+			child, ok := node[name]
+			if !ok {
+				child = make(fieldSet)
+				node[name] = child
+			}
+			node, selected = child, true
+		}
+		if selected {
+			node[wholeField] = nil
+		}
+	}
+
+	if len(set) == 0 {
+		return nil
+	}
+	return set
+}
+
+// selectFields prunes a successful body down to the fields selected by the
+// request, returning the pruned JSON as a prunedBody. Bodies that cannot be pruned are
+// returned as they are.
+func (resp *Response) selectFields(enc Encoder, code int, v interface{}) interface{} {
+	if !resp.SparseFields || resp.Request == nil || resp.Request.URL == nil || v == nil {
+		return v
+	}
+	if code < 200 || code > 299 || !isJSONMediaType(enc.ContentType()) {
+		return v
+	}
+	f := resp.Formatter
+	if f == nil {
+		f = DefaultFormatter
+	}
+	switch f.(type) {
+	case JSONAPI, *JSONAPI:
+		return v
+	}
+	if _, ok := v.(mediaTyper); ok {
+		return v
+	}
+
+	fields := parseFields(resp.Request.URL.Query().Get(fieldsParam))
+	if fields == nil {
+		return v
+	}
+
+	body, err := json.Marshal(v)
+	if err != nil {
+		return v
+	}
+	pruned, err := pruneJSON(body, fields)
+	if err != nil {
+		return v
+	}
+	return prunedBody(pruned)
+}
+
+// prunedBody is a body pruned to the selected fields, encoded as it is
+type prunedBody []byte
+
+// MarshalJSON returns the pruned JSON
+func (b prunedBody) MarshalJSON() ([]byte, error) {
+	return b, nil
+}
+
+// isJSONMediaType reports whether the content type is JSON or a +json type
+func isJSONMediaType(contentType string) bool {
+	mediaType := baseMediaType(contentType)
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
+
+// pruneJSON removes the members of the objects in data that are not in
+// fields, keeping the order of the remaining members. The fields apply to
+// each element of arrays.
+func pruneJSON(data []byte, fields fieldSet) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	switch tok {
+	case json.Delim('{'):
+		buf.WriteByte('{')
+		first := true
+		for dec.More() {
+			tok, err := dec.Token()
+			if err != nil {
+				return nil, err
+			}
+			key, _ := tok.(string)
+
+			var value json.RawMessage
+			if err := dec.Decode(&value); err != nil {
+				return nil, err
+			}
+
+			children, ok := fields[key]
+			if !ok {
+				continue
+			}
+			if _, whole := children[wholeField]; !whole {
+				if value, err = pruneJSON(value, children); err != nil {
+					return nil, err
+				}
+			}
+
+			if !first {
+				buf.WriteByte(',')
+			}
+			first = false
+			name, _ := json.Marshal(key)
+			buf.Write(name)
+			buf.WriteByte(':')
+			buf.Write(value)
+		}
+		buf.WriteByte('}')
+
+	case json.Delim('['):
+		buf.WriteByte('[')
+		for i := 0; dec.More(); i++ {
+			var value json.RawMessage
+			if err := dec.Decode(&value); err != nil {
+				return nil, err
+			}
+			if value, err = pruneJSON(value, fields); err != nil {
+				return nil, err
+			}
+
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			buf.Write(value)
+		}
+		buf.WriteByte(']')
+
+	default:
+		return data, nil
+	}
+
+	return buf.Bytes(), nil
+}
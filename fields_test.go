@@ -0,0 +1,130 @@
+package respond
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type address struct {
+	Street string `json:"street"`
+	City   string `json:"city"`
+}
+
+type customer struct {
+	ID      int       `json:"id"`
+	Name    string    `json:"name"`
+	Address address   `json:"address"`
+	Orders  []address `json:"orders"`
+}
+
+var sparseCustomer = customer{
+	ID:      1,
+	Name:    "Billy",
+	Address: address{"1 Main St", "Springfield"},
+	Orders:  []address{{"2 High St", "Shelbyville"}, {"3 Low St", "Ogdenville"}},
+}
+
+var fieldsData = []struct {
+	testName string
+
+	url     string
+	respond func(resp *Response) error
+
+	expectedBody string
+}{
+	{"no fields", "/customers/1",
+		func(resp *Response) error { return resp.Ok(sparseCustomer) },
+		`{"id":1,"name":"Billy","address":{"street":"1 Main St","city":"Springfield"},` +
+			`"orders":[{"street":"2 High St","city":"Shelbyville"},{"street":"3 Low St","city":"Ogdenville"}]}`},
+	{"top level fields in body order", "/customers/1?fields=name,id",
+		func(resp *Response) error { return resp.Ok(sparseCustomer) },
+		`{"id":1,"name":"Billy"}`},
+	{"nested fields", "/customers/1?fields=id,address.city,orders.street",
+		func(resp *Response) error { return resp.Ok(sparseCustomer) },
+		`{"id":1,"address":{"city":"Springfield"},"orders":[{"street":"2 High St"},{"street":"3 Low St"}]}`},
+	{"whole object", "/customers/1?fields=address,address.city",
+		func(resp *Response) error { return resp.Ok(sparseCustomer) },
+		`{"address":{"street":"1 Main St","city":"Springfield"}}`},
+	{"collection", "/customers?fields=name",
+		func(resp *Response) error { return resp.Ok([]customer{sparseCustomer, {ID: 2, Name: "Jill"}}) },
+		`[{"name":"Billy"},{"name":"Jill"}]`},
+	{"envelope", "/customers/1?fields=id",
+		func(resp *Response) error { return resp.Format(&Envelope{}).Meta("version", "v2").Ok(sparseCustomer) },
+		`{"data":{"id":1},"meta":{"version":"v2"}}`},
+	{"hal", "/customers/1?fields=id,name",
+		func(resp *Response) error { return resp.HAL().Ok(sparseCustomer) },
+		`{"_links":{"self":{"href":"/customers/1?fields=id,name"}},"id":1,"name":"Billy"}`},
+	{"hal collection", "/customers?fields=name",
+		func(resp *Response) error { return resp.HAL().Ok([]customer{sparseCustomer, {ID: 2, Name: "Jill"}}) },
+		`{"_links":{"self":{"href":"/customers?fields=name"}},"_embedded":{"items":[{"name":"Billy"},{"name":"Jill"}]}}`},
+	{"error body", "/customers/1?fields=id",
+		func(resp *Response) error { return resp.NotFound(&Error{404, "Not found"}) },
+		`{"code":404,"message":"Not found"}`},
+	{"json:api", "/customers/1?fields=id",
+		func(resp *Response) error { return resp.JSONAPI().Ok(&person{"9", "Billy"}) },
+		`{"data":{"type":"people","id":"9","attributes":{"name":"Billy"}},"links":{"self":"/customers/1?fields=id"}}`},
+}
+
+func TestSparseFieldsets(t *testing.T) {
+	for _, datum := range fieldsData {
+		datum := datum
+		t.Run(datum.testName, func(t *testing.T) {
+			t.Parallel()
+
+			req, err := http.NewRequest("GET", datum.url, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			rr := httptest.NewRecorder()
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				datum.respond(NewResponse(w).WithRequest(r).SparseFieldsets())
+			})
+			handler.ServeHTTP(rr, req)
+
+			if err := validateResponseBody(rr.Body.String(), datum.expectedBody); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestSparseFieldsetsDisabled(t *testing.T) {
+	t.Parallel()
+
+	req, err := http.NewRequest("GET", "/users/1?fields=id", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		NewResponse(w).WithRequest(r).Ok(&User{1, "Billy", "billy@example.com"})
+	})
+	handler.ServeHTTP(rr, req)
+
+	if err := validateResponseBody(rr.Body.String(), `{"id":1,"name":"Billy","email":"billy@example.com"}`); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSparseFieldsetsXML(t *testing.T) {
+	t.Parallel()
+
+	req, err := http.NewRequest("GET", "/users/1?fields=id", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		NewResponse(w).WithRequest(r).WithEncoder(XMLEncoder{}).SparseFieldsets().Ok(DefaultMessageResponse{Status: 200, Message: "OK"})
+	})
+	handler.ServeHTTP(rr, req)
+
+	expected := `<?xml version="1.0" encoding="UTF-8"?>` + "\n" + `<response><status>200</status><message>OK</message></response>`
+	if err := validateResponseBody(rr.Body.String(), expected); err != nil {
+		t.Fatal(err)
+	}
+}
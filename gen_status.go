@@ -0,0 +1,229 @@
+//go:build ignore
+// +build ignore
+
+// This program generates the status methods of Response, and their tests,
+// from the table of IANA registered status codes below. Run it with
+// go generate.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"text/template"
+)
+
+type kind int
+
+const (
+	// withBody methods take the value to send as the body
+	withBody kind = iota
+	// noBody methods take no value, as the status does not allow content
+	noBody
+	// redirect methods take the target URL and the value to send as the body
+	redirect
+)
+
+type status struct {
+	Code     int
+	Constant string
+	Method   string
+	Kind     kind
+}
+
+func (s status) Text() string {
+	return http.StatusText(s.Code)
+}
+
+func (s status) WithBody() bool {
+	return s.Kind == withBody
+}
+
+func (s status) Redirect() bool {
+	return s.Kind == redirect
+}
+
+// statuses lists every final status code in the IANA HTTP Status Code
+// Registry. Informational (1xx) codes are not final responses, and codes the
+// registry marks as unused (306, 418) or deprecated (305) are left out.
+var statuses = []status{
+	{200, "StatusOK", "Ok", withBody},
+	{201, "StatusCreated", "Created", withBody},
+	{202, "StatusAccepted", "Accepted", withBody},
+	{203, "StatusNonAuthoritativeInfo", "NonAuthoritativeInfo", withBody},
+	{204, "StatusNoContent", "NoContent", noBody},
+	{205, "StatusResetContent", "ResetContent", noBody},
+	{206, "StatusPartialContent", "PartialContent", withBody},
+	{207, "StatusMultiStatus", "MultiStatus", withBody},
+	{208, "StatusAlreadyReported", "AlreadyReported", withBody},
+	{226, "StatusIMUsed", "IMUsed", withBody},
+
+	{300, "StatusMultipleChoices", "MultipleChoices", withBody},
+	{301, "StatusMovedPermanently", "MovedPermanently", redirect},
+	{302, "StatusFound", "Found", redirect},
+	{303, "StatusSeeOther", "SeeOther", redirect},
+	{304, "StatusNotModified", "NotModified", noBody},
+	{307, "StatusTemporaryRedirect", "TemporaryRedirect", redirect},
+	{308, "StatusPermanentRedirect", "PermanentRedirect", redirect},
+
+	{400, "StatusBadRequest", "BadRequest", withBody},
+	{401, "StatusUnauthorized", "Unauthorized", withBody},
+	{402, "StatusPaymentRequired", "PaymentRequired", withBody},
+	{403, "StatusForbidden", "Forbidden", withBody},
+	{404, "StatusNotFound", "NotFound", withBody},
+	{405, "StatusMethodNotAllowed", "MethodNotAllowed", withBody},
+	{406, "StatusNotAcceptable", "NotAcceptable", withBody},
+	{407, "StatusProxyAuthRequired", "ProxyAuthRequired", withBody},
+	{408, "StatusRequestTimeout", "RequestTimeout", withBody},
+	{409, "StatusConflict", "Conflict", withBody},
+	{410, "StatusGone", "Gone", withBody},
+	{411, "StatusLengthRequired", "LengthRequired", withBody},
+	{412, "StatusPreconditionFailed", "PreconditionFailed", withBody},
+	{413, "StatusRequestEntityTooLarge", "RequestEntityTooLarge", withBody},
+	{414, "StatusRequestURITooLong", "RequestURITooLong", withBody},
+	{415, "StatusUnsupportedMediaType", "UnsupportedMediaType", withBody},
+	{416, "StatusRequestedRangeNotSatisfiable", "RequestedRangeNotSatisfiable", withBody},
+	{417, "StatusExpectationFailed", "ExpectationFailed", withBody},
+	{421, "StatusMisdirectedRequest", "MisdirectedRequest", withBody},
+	{422, "StatusUnprocessableEntity", "UnprocessableEntity", withBody},
+	{423, "StatusLocked", "Locked", withBody},
+	{424, "StatusFailedDependency", "FailedDependency", withBody},
+	{425, "StatusTooEarly", "TooEarly", withBody},
+	{426, "StatusUpgradeRequired", "UpgradeRequired", withBody},
+	{428, "StatusPreconditionRequired", "PreconditionRequired", withBody},
+	{429, "StatusTooManyRequests", "TooManyRequests", withBody},
+	{431, "StatusRequestHeaderFieldsTooLarge", "RequestHeaderFieldsTooLarge", withBody},
+	{451, "StatusUnavailableForLegalReasons", "UnavailableForLegalReasons", withBody},
+
+	{500, "StatusInternalServerError", "InternalServerError", withBody},
+	{501, "StatusNotImplemented", "NotImplemented", withBody},
+	{502, "StatusBadGateway", "BadGateway", withBody},
+	{503, "StatusServiceUnavailable", "ServiceUnavailable", withBody},
+	{504, "StatusGatewayTimeout", "GatewayTimeout", withBody},
+	{505, "StatusHTTPVersionNotSupported", "HTTPVersionNotSupported", withBody},
+	{506, "StatusVariantAlsoNegotiates", "VariantAlsoNegotiates", withBody},
+	{507, "StatusInsufficientStorage", "InsufficientStorage", withBody},
+	{508, "StatusLoopDetected", "LoopDetected", withBody},
+	{510, "StatusNotExtended", "NotExtended", withBody},
+	{511, "StatusNetworkAuthenticationRequired", "NetworkAuthenticationRequired", withBody},
+}
+
+const header = `// Code generated by gen_status.go. DO NOT EDIT.
+
+package respond
+`
+
+var methodsTemplate = template.Must(template.New("methods").Parse(header + `
+import "net/http"
+{{range .}}
+{{if .WithBody -}}
+// {{.Method}} returns a {{.Code}} {{.Text}} JSON response
+func (resp *Response) {{.Method}}(v interface{}) error {
+	return resp.writeResponse(http.{{.Constant}}, v)
+}
+{{- else if .Redirect -}}
+// {{.Method}} redirects to url with a {{.Code}} {{.Text}} response and an
+// optional JSON body
+func (resp *Response) {{.Method}}(url string, v interface{}) error {
+	return resp.redirect(http.{{.Constant}}, url, v)
+}
+{{- else -}}
+// {{.Method}} returns a {{.Code}} {{.Text}} response
+func (resp *Response) {{.Method}}() error {
+	return resp.writeResponse(http.{{.Constant}}, nil)
+}
+{{- end}}
+{{end}}`))
+
+var testsTemplate = template.Must(template.New("tests").Parse(header + `
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+{{range .}}
+func TestStatus{{.Method}}(t *testing.T) {
+	t.Parallel()
+
+	req := newRequest(t, "GET")
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		NewResponse(w).
+{{- if .WithBody}}
+			{{.Method}}(&Error{ {{- .Code}}, "{{.Text}}"})
+{{- else if .Redirect}}
+			DefaultMessage().
+			{{.Method}}("/users/1", nil)
+{{- else}}
+			DefaultMessage().
+			{{.Method}}()
+{{- end}}
+	})
+	handler.ServeHTTP(rr, req)
+
+	if err := validateStatusCode(rr.Code, http.{{.Constant}}); err != nil {
+		t.Fatal(err.Error())
+	}
+
+{{if .Redirect -}}
+	if err := validateResponseHeader(rr.Header().Get("Location"), "/users/1"); err != nil {
+		t.Fatal(err.Error())
+	}
+
+{{end -}}
+{{if .WithBody -}}
+	expected := ` + "`" + `{"code":{{.Code}},"message":"{{.Text}}"}` + "`" + `
+{{- else if .Redirect -}}
+	expected := ` + "`" + `{"status":{{.Code}},"message":"{{.Text}}","location":"/users/1"}` + "`" + `
+{{- else -}}
+	expected := ""
+{{- end}}
+	if err := validateResponseBody(rr.Body.String(), expected); err != nil {
+		t.Fatal(err.Error())
+	}
+}
+{{end}}`))
+
+func main() {
+	var success, redirection, clientServerError []status
+	for _, s := range statuses {
+		if http.StatusText(s.Code) == "" {
+			log.Fatalf("unknown status code %d", s.Code)
+		}
+
+		switch {
+		case s.Code < 300:
+			success = append(success, s)
+		case s.Code < 400:
+			redirection = append(redirection, s)
+		default:
+			clientServerError = append(clientServerError, s)
+		}
+	}
+
+	generate("success.go", methodsTemplate, success)
+	generate("redirection.go", methodsTemplate, redirection)
+	generate("error.go", methodsTemplate, clientServerError)
+	generate("status_gen_test.go", testsTemplate, statuses)
+}
+
+func generate(filename string, tmpl *template.Template, data []status) {
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		log.Fatal(err)
+	}
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatalf("formatting %s: %v\n%s", filename, err, buf.Bytes())
+	}
+
+	if err := ioutil.WriteFile(filename, src, 0644); err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println("wrote", filename)
+}
@@ -0,0 +1,251 @@
+package respond
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+)
+
+// halMediaType is the media type of HAL documents
+const halMediaType = "application/hal+json"
+
+// halItemsRel is the relation collections are embedded under
+const halItemsRel = "items"
+
+// ErrInvalidRepresentation is returned when the value of a HAL
+// representation does not encode to a JSON object
+var ErrInvalidRepresentation = errors.New("respond: HAL representation value must encode to a JSON object")
+
+// HALEncoder encodes response bodies as JSON, sent with the HAL media type
+type HALEncoder struct{}
+
+// ContentType returns the HAL media type
+func (HALEncoder) ContentType() string {
+	return halMediaType
+}
+
+// Encode returns the JSON encoding of v
+func (HALEncoder) Encode(v interface{}) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+// EncodeIndent returns the indented JSON encoding of v
+func (HALEncoder) EncodeIndent(v interface{}, indent string) ([]byte, error) {
+	return json.MarshalIndent(v, "", indent)
+}
+
+// HALLink is a HAL link object
+type HALLink struct {
+	Href        string `json:"href"`
+	Templated   bool   `json:"templated,omitempty"`
+	Type        string `json:"type,omitempty"`
+	Deprecation string `json:"deprecation,omitempty"`
+	Name        string `json:"name,omitempty"`
+	Profile     string `json:"profile,omitempty"`
+	Title       string `json:"title,omitempty"`
+	Hreflang    string `json:"hreflang,omitempty"`
+}
+
+// Representation is a HAL resource: a value whose members are sent along
+// with _links and _embedded members
+type Representation struct {
+	Value    interface{}
+	Links    map[string][]HALLink
+	Embedded map[string][]interface{}
+}
+
+// NewRepresentation creates a HAL representation of v, which must encode to
+// a JSON object
+func NewRepresentation(v interface{}) *Representation {
+	return &Representation{Value: v}
+}
+
+// Link adds a link to the representation
+func (r *Representation) Link(rel, href string) *Representation {
+	return r.AddLink(rel, HALLink{Href: href})
+}
+
+// LinkTemplate adds a templated link, whose href is a URI template, to the
+// representation
+func (r *Representation) LinkTemplate(rel, href string) *Representation {
+	return r.AddLink(rel, HALLink{Href: href, Templated: true})
+}
+
+// AddLink adds a link object to the representation. Relations with more
+// than one link are sent as an array.
+func (r *Representation) AddLink(rel string, link HALLink) *Representation {
+	if r.Links == nil {
+		r.Links = make(map[string][]HALLink)
+	}
+	r.Links[rel] = append(r.Links[rel], link)
+	return r
+}
+
+// Curie adds a CURIE, naming a templated link to the documentation of
+// relations prefixed with name
+func (r *Representation) Curie(name, href string) *Representation {
+	return r.AddLink("curies", HALLink{Href: href, Templated: true, Name: name})
+}
+
+// Embed adds an embedded resource, usually a Representation or a slice of
+// them, to the representation. Relations embedded more than once are sent as
+// an array.
+func (r *Representation) Embed(rel string, v interface{}) *Representation {
+	if r.Embedded == nil {
+		r.Embedded = make(map[string][]interface{})
+	}
+	r.Embedded[rel] = append(r.Embedded[rel], v)
+	return r
+}
+
+// MarshalJSON encodes the representation as the members of its value,
+// preceded by _links and followed by _embedded
+func (r Representation) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+
+	first := true
+	writeMembers := func(members []byte) {
+		if len(members) == 0 {
+			return
+		}
+		if !first {
+			buf.WriteByte(',')
+		}
+		first = false
+		buf.Write(members)
+	}
+	write := func(key string, value interface{}) error {
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		name, _ := json.Marshal(key)
+		writeMembers(append(append(name, ':'), encoded...))
+		return nil
+	}
+
+	if len(r.Links) > 0 {
+		links := make(map[string]interface{}, len(r.Links))
+		for rel, l := range r.Links {
+			if len(l) == 1 && rel != "curies" {
+				links[rel] = l[0]
+			} else {
+				links[rel] = l
+			}
+		}
+		if err := write("_links", links); err != nil {
+			return nil, err
+		}
+	}
+
+	if r.Value != nil {
+		value, err := json.Marshal(r.Value)
+		if err != nil {
+			return nil, err
+		}
+		value = bytes.TrimSpace(value)
+		if !bytes.Equal(value, []byte("null")) {
+			if len(value) < 2 || value[0] != '{' {
+				return nil, ErrInvalidRepresentation
+			}
+			writeMembers(bytes.TrimSpace(value[1 : len(value)-1]))
+		}
+	}
+
+	if len(r.Embedded) > 0 {
+		embedded := make(map[string]interface{}, len(r.Embedded))
+		for rel, e := range r.Embedded {
+			if len(e) == 1 {
+				embedded[rel] = e[0]
+			} else {
+				embedded[rel] = e
+			}
+		}
+		if err := write("_embedded", embedded); err != nil {
+			return nil, err
+		}
+	}
+
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// mediaType sends JSON encoded representations with the HAL media type
+func (Representation) mediaType(contentType string) string {
+	if baseMediaType(contentType) == "application/json" {
+		return halMediaType
+	}
+	return contentType
+}
+
+// HAL is a Formatter sending successful bodies as HAL representations.
+// Bodies that are not already a Representation are wrapped in one, with
+// collections embedded as items. A self link to the request URL is added,
+// along with the links to the other pages when paginated. Error bodies, and
+// bodies negotiated to an encoding other than JSON, are sent as they are,
+// with only the value of a Representation.
+type HAL struct{}
+
+// representationValue returns the value of a representation, or v when it
+// is not one
+func representationValue(v interface{}) interface{} {
+	switch r := v.(type) {
+	case *Representation:
+		if r != nil {
+			return r.Value
+		}
+	case Representation:
+		return r.Value
+	}
+	return v
+}
+
+// HAL formats successful responses as HAL representations, and accepts
+// requests for the HAL media type
+func (resp *Response) HAL() *Response {
+	resp.Formatter = HAL{}
+	return resp.AddEncoder(HALEncoder{})
+}
+
+// Format converts the body into a Representation
+func (HAL) Format(resp *Response, code int, v interface{}) interface{} {
+	if code < 200 || code > 299 {
+		return v
+	}
+
+	var rep Representation
+	switch r := v.(type) {
+	case *Representation:
+		rep = *r
+	case Representation:
+		rep = r
+	default:
+		if isCollection(v) {
+			rep = Representation{Embedded: map[string][]interface{}{halItemsRel: {v}}}
+		} else {
+			rep = Representation{Value: v}
+		}
+	}
+
+	links := make(map[string][]HALLink, len(rep.Links))
+	for rel, l := range rep.Links {
+		links[rel] = l
+	}
+	rep.Links = links
+
+	if resp.Request != nil && resp.Request.URL != nil {
+		if _, ok := rep.Links["self"]; !ok {
+			rep.Links["self"] = []HALLink{{Href: resp.Request.URL.RequestURI()}}
+		}
+		if resp.Page != nil {
+			for _, l := range resp.Page.links(resp.Request.URL) {
+				if _, ok := rep.Links[l.rel]; !ok {
+					rep.Links[l.rel] = []HALLink{{Href: l.href}}
+				}
+			}
+		}
+	}
+
+	return rep
+}
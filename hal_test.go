@@ -0,0 +1,173 @@
+package respond
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+var halData = []struct {
+	testName string
+
+	url     string
+	respond func(resp *Response) error
+
+	expectedStatus int
+	expectedBody   string
+}{
+	{"value", "/users/1",
+		func(resp *Response) error { return resp.Ok(&User{1, "Billy", "billy@example.com"}) },
+		http.StatusOK,
+		`{"_links":{"self":{"href":"/users/1"}},"id":1,"name":"Billy","email":"billy@example.com"}`},
+	{"representation", "/users/1",
+		func(resp *Response) error {
+			return resp.Created(NewRepresentation(&User{1, "Billy", "billy@example.com"}).
+				Curie("acme", "https://docs.example.com/rels/{rel}").
+				Link("acme:posts", "/users/1/posts").
+				LinkTemplate("search", "/users{?q}").
+				Embed("acme:manager", NewRepresentation(map[string]string{"name": "Jill"}).Link("self", "/users/2")))
+		},
+		http.StatusCreated,
+		`{"_links":{"acme:posts":{"href":"/users/1/posts"},` +
+			`"curies":[{"href":"https://docs.example.com/rels/{rel}","templated":true,"name":"acme"}],` +
+			`"search":{"href":"/users{?q}","templated":true},"self":{"href":"/users/1"}},` +
+			`"id":1,"name":"Billy","email":"billy@example.com",` +
+			`"_embedded":{"acme:manager":{"_links":{"self":{"href":"/users/2"}},"name":"Jill"}}}`},
+	{"explicit self link", "/users/1",
+		func(resp *Response) error {
+			return resp.Ok(NewRepresentation(nil).Link("self", "/users/billy").Link("alternate", "/u/1").Link("alternate", "/u/b"))
+		},
+		http.StatusOK,
+		`{"_links":{"alternate":[{"href":"/u/1"},{"href":"/u/b"}],"self":{"href":"/users/billy"}}}`},
+	{"collection", "/users?limit=1",
+		func(resp *Response) error {
+			return resp.Paginate(OffsetPage(0, 1, 2)).Ok([]User{{1, "Billy", "billy@example.com"}})
+		},
+		http.StatusOK,
+		`{"_links":{"first":{"href":"/users?limit=1\u0026offset=0"},"last":{"href":"/users?limit=1\u0026offset=1"},` +
+			`"next":{"href":"/users?limit=1\u0026offset=1"},"self":{"href":"/users?limit=1"}},` +
+			`"_embedded":{"items":[{"id":1,"name":"Billy","email":"billy@example.com"}]}}`},
+}
+
+func TestHAL(t *testing.T) {
+	for _, datum := range halData {
+		datum := datum
+		t.Run(datum.testName, func(t *testing.T) {
+			t.Parallel()
+
+			req, err := http.NewRequest("GET", datum.url, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			rr := httptest.NewRecorder()
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				datum.respond(NewResponse(w).WithRequest(r).HAL())
+			})
+			handler.ServeHTTP(rr, req)
+
+			if err := validateStatusCode(rr.Code, datum.expectedStatus); err != nil {
+				t.Fatal(err)
+			}
+
+			if err := validateResponseHeader(rr.Header().Get("Content-Type"), "application/hal+json"); err != nil {
+				t.Fatal(err)
+			}
+
+			if err := validateResponseBody(rr.Body.String(), datum.expectedBody); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestHALError(t *testing.T) {
+	t.Parallel()
+
+	req := newRequest(t, "GET")
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		NewResponse(w).WithRequest(r).HAL().NotFound(&Error{404, "Not found"})
+	})
+	handler.ServeHTTP(rr, req)
+
+	if err := validateResponseHeader(rr.Header().Get("Content-Type"), "application/json; charset=utf-8"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := validateResponseBody(rr.Body.String(), `{"code":404,"message":"Not found"}`); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestHALOtherEncoding(t *testing.T) {
+	t.Parallel()
+
+	req := newRequest(t, "GET")
+	req.Header.Set("Accept", "application/xml")
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		NewResponse(w).
+			WithRequest(r).
+			AddEncoder(XMLEncoder{}).
+			HAL().
+			Ok(NewRepresentation(&Error{1, "a"}).Link("self", "/errors/1"))
+	})
+	handler.ServeHTTP(rr, req)
+
+	if err := validateStatusCode(rr.Code, http.StatusOK); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := validateResponseHeader(rr.Header().Get("Content-Type"), "application/xml; charset=utf-8"); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `<?xml version="1.0" encoding="UTF-8"?>` + "\n" + `<Error><Code>1</Code><Message>a</Message></Error>`
+	if err := validateResponseBody(rr.Body.String(), expected); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRepresentationWithoutFormatter(t *testing.T) {
+	t.Parallel()
+
+	req := newRequest(t, "GET")
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		NewResponse(w).Ok(NewRepresentation(&Error{1, "a"}).Link("self", "/errors/1"))
+	})
+	handler.ServeHTTP(rr, req)
+
+	if err := validateResponseHeader(rr.Header().Get("Content-Type"), "application/hal+json"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := validateResponseBody(rr.Body.String(), `{"_links":{"self":{"href":"/errors/1"}},"code":1,"message":"a"}`); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRepresentationInvalidValue(t *testing.T) {
+	t.Parallel()
+
+	req := newRequest(t, "GET")
+
+	var err error
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		err = NewResponse(w).Ok(NewRepresentation("text"))
+	})
+	handler.ServeHTTP(rr, req)
+
+	if err := validateStatusCode(rr.Code, http.StatusInternalServerError); err != nil {
+		t.Fatal(err)
+	}
+
+	if err == nil {
+		t.Fatal("expected an error for a value that is not an object")
+	}
+}
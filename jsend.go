@@ -0,0 +1,115 @@
+package respond
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"net/http"
+)
+
+// JSend statuses
+const (
+	JSendSuccess = "success"
+	JSendFail    = "fail"
+	JSendError   = "error"
+)
+
+// JSend is a Formatter producing JSend bodies. 2xx and 3xx responses are a
+// success holding the body as data, 4xx responses are a fail holding the
+// body as data, and 5xx responses are an error with the status text as
+// message and the body as data. Default messages are sent as a success
+// without data, a fail holding the default message, and an error with the
+// default message, respectively.
+type JSend struct{}
+
+// JSendBody is the body of a JSend response
+type JSendBody struct {
+	Status  string
+	Data    interface{}
+	Message string
+	Code    int
+}
+
+// Format wraps the body in a JSendBody
+func (JSend) Format(resp *Response, code int, v interface{}) interface{} {
+	switch {
+	case code < http.StatusBadRequest:
+		if _, ok := v.(DefaultMessageResponse); ok {
+			v = nil
+		}
+		return JSendBody{Status: JSendSuccess, Data: v}
+
+	case code < http.StatusInternalServerError:
+		return JSendBody{Status: JSendFail, Data: v}
+	}
+
+	body := JSendBody{
+		Status:  JSendError,
+		Message: http.StatusText(code),
+		Code:    code,
+		Data:    v,
+	}
+	switch e := v.(type) {
+	case DefaultMessageResponse:
+		body.Message, body.Data = e.Message, nil
+	case Problem:
+		body.Message, body.Data = problemMessage(e), nil
+	case *Problem:
+		body.Message, body.Data = problemMessage(*e), nil
+	}
+	return body
+}
+
+// problemMessage returns the most specific description of a problem
+func problemMessage(p Problem) string {
+	if p.Detail != "" {
+		return p.Detail
+	}
+	return p.Title
+}
+
+// MarshalJSON encodes the body, always including data for a success or
+// fail, and only including data and code when set for an error
+func (b JSendBody) MarshalJSON() ([]byte, error) {
+	if b.Status == JSendError {
+		return json.Marshal(struct {
+			Status  string      `json:"status"`
+			Message string      `json:"message"`
+			Code    int         `json:"code,omitempty"`
+			Data    interface{} `json:"data,omitempty"`
+		}{b.Status, b.Message, b.Code, b.Data})
+	}
+
+	return json.Marshal(struct {
+		Status string      `json:"status"`
+		Data   interface{} `json:"data"`
+	}{b.Status, b.Data})
+}
+
+// MarshalXML encodes the body as a response element
+func (b JSendBody) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	start = xml.StartElement{Name: xml.Name{Local: "response"}}
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+
+	if err := e.EncodeElement(b.Status, xml.StartElement{Name: xml.Name{Local: "status"}}); err != nil {
+		return err
+	}
+	if b.Message != "" {
+		if err := e.EncodeElement(b.Message, xml.StartElement{Name: xml.Name{Local: "message"}}); err != nil {
+			return err
+		}
+	}
+	if b.Code != 0 {
+		if err := e.EncodeElement(b.Code, xml.StartElement{Name: xml.Name{Local: "code"}}); err != nil {
+			return err
+		}
+	}
+	if b.Data != nil {
+		if err := encodeXMLWrapped(e, "data", b.Data); err != nil {
+			return err
+		}
+	}
+
+	return e.EncodeToken(start.End())
+}
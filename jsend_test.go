@@ -0,0 +1,71 @@
+package respond
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+var jsendData = []struct {
+	testName string
+
+	respond func(resp *Response) error
+
+	expectedStatus int
+	expectedBody   string
+}{
+	{"success",
+		func(resp *Response) error { return resp.Ok(&User{1, "Billy", "billy@example.com"}) },
+		http.StatusOK, `{"status":"success","data":{"id":1,"name":"Billy","email":"billy@example.com"}}`},
+	{"success default message",
+		func(resp *Response) error { return resp.DefaultMessage().Accepted(nil) },
+		http.StatusAccepted, `{"status":"success","data":null}`},
+	{"fail",
+		func(resp *Response) error {
+			return resp.UnprocessableEntity(map[string]string{"email": "Email is invalid"})
+		},
+		http.StatusUnprocessableEntity, `{"status":"fail","data":{"email":"Email is invalid"}}`},
+	{"fail default message",
+		func(resp *Response) error { return resp.DefaultMessage().NotFound(nil) },
+		http.StatusNotFound, `{"status":"fail","data":{"status":404,"message":"Not Found"}}`},
+	{"error",
+		func(resp *Response) error { return resp.ServiceUnavailable(map[string]int{"retry_after": 30}) },
+		http.StatusServiceUnavailable, `{"status":"error","message":"Service Unavailable","code":503,"data":{"retry_after":30}}`},
+	{"error default message",
+		func(resp *Response) error { return resp.DefaultMessage().InternalServerError(nil) },
+		http.StatusInternalServerError, `{"status":"error","message":"Internal Server Error","code":500}`},
+	{"error problem",
+		func(resp *Response) error {
+			return resp.Problem(NewProblem(http.StatusBadGateway, "Upstream timed out"))
+		},
+		http.StatusBadGateway, `{"status":"error","message":"Upstream timed out","code":502}`},
+}
+
+func TestJSend(t *testing.T) {
+	for _, datum := range jsendData {
+		datum := datum
+		t.Run(datum.testName, func(t *testing.T) {
+			t.Parallel()
+
+			req := newRequest(t, "GET")
+
+			rr := httptest.NewRecorder()
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				datum.respond(NewResponse(w).Format(JSend{}))
+			})
+			handler.ServeHTTP(rr, req)
+
+			if err := validateStatusCode(rr.Code, datum.expectedStatus); err != nil {
+				t.Fatal(err)
+			}
+
+			if err := validateResponseHeader(rr.Header().Get("Content-Type"), "application/json; charset=utf-8"); err != nil {
+				t.Fatal(err)
+			}
+
+			if err := validateResponseBody(rr.Body.String(), datum.expectedBody); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
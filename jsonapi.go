@@ -0,0 +1,456 @@
+package respond
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// jsonapiMediaType is the media type of JSON:API documents
+const jsonapiMediaType = "application/vnd.api+json"
+
+// JSONAPIEncoder encodes response bodies as JSON, sent with the JSON:API
+// media type
+type JSONAPIEncoder struct{}
+
+// ContentType returns the JSON:API media type
+func (JSONAPIEncoder) ContentType() string {
+	return jsonapiMediaType
+}
+
+// Encode returns the JSON encoding of v
+func (JSONAPIEncoder) Encode(v interface{}) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+// EncodeIndent returns the indented JSON encoding of v
+func (JSONAPIEncoder) EncodeIndent(v interface{}, indent string) ([]byte, error) {
+	return json.MarshalIndent(v, "", indent)
+}
+
+// JSONAPI is a Formatter producing JSON:API documents. Successful bodies
+// become the primary data, with structs tagged as resources converted to
+// resource objects and their relations added to the included resources.
+// Error bodies become error objects. The links hold the request URL as
+// self, along with the links to the other pages when paginated.
+//
+// Resources are described with jsonapi struct tags:
+//
+//	type Article struct {
+//		ID     int     `jsonapi:"primary,articles"`
+//		Title  string  `jsonapi:"attr,title"`
+//		Body   string  `jsonapi:"attr,body,omitempty"`
+//		Author *Person `jsonapi:"relation,author"`
+//	}
+//
+// Relation fields hold resources, pointers to them, or slices of either.
+type JSONAPI struct{}
+
+// JSONAPI formats the response as a JSON:API document, always sent with the
+// JSON:API media type
+func (resp *Response) JSONAPI() *Response {
+	resp.Formatter = JSONAPI{}
+	return resp.WithEncoder(JSONAPIEncoder{})
+}
+
+// JSONAPIDocument is the top level of a JSON:API document
+type JSONAPIDocument struct {
+	Data     interface{}
+	Included []*Resource
+	Meta     Meta
+	Links    map[string]string
+	Errors   []*JSONAPIError
+}
+
+// Resource is a JSON:API resource object
+type Resource struct {
+	Type          string                   `json:"type"`
+	ID            string                   `json:"id,omitempty"`
+	Attributes    map[string]interface{}   `json:"attributes,omitempty"`
+	Relationships map[string]*Relationship `json:"relationships,omitempty"`
+}
+
+// Relationship is a JSON:API relationship object. Data is nil, a
+// ResourceIdentifier or a slice of them.
+type Relationship struct {
+	Data interface{} `json:"data"`
+}
+
+// ResourceIdentifier identifies a JSON:API resource
+type ResourceIdentifier struct {
+	Type string `json:"type"`
+	ID   string `json:"id"`
+}
+
+// JSONAPIError is a JSON:API error object. Status defaults to the status
+// code of the response.
+type JSONAPIError struct {
+	ID     string              `json:"id,omitempty"`
+	Links  map[string]string   `json:"links,omitempty"`
+	Status string              `json:"status,omitempty"`
+	Code   string              `json:"code,omitempty"`
+	Title  string              `json:"title,omitempty"`
+	Detail string              `json:"detail,omitempty"`
+	Source *JSONAPIErrorSource `json:"source,omitempty"`
+	Meta   interface{}         `json:"meta,omitempty"`
+}
+
+// JSONAPIErrorSource points to the part of the request that caused an error
+type JSONAPIErrorSource struct {
+	Pointer   string `json:"pointer,omitempty"`
+	Parameter string `json:"parameter,omitempty"`
+	Header    string `json:"header,omitempty"`
+}
+
+// jsonapiErrorer is implemented by bodies that convert to JSON:API error
+// objects, given the status code of the response
+type jsonapiErrorer interface {
+	jsonapiErrors(code int) []*JSONAPIError
+}
+
+// Format converts the body into a JSONAPIDocument
+func (JSONAPI) Format(resp *Response, code int, v interface{}) interface{} {
+	doc := JSONAPIDocument{}
+
+	if code >= http.StatusBadRequest {
+		doc.Errors = jsonapiErrors(code, v)
+	} else if _, ok := v.(DefaultMessageResponse); !ok {
+		b := resourceBuilder{seen: make(map[ResourceIdentifier]bool)}
+		doc.Data = b.data(v)
+		doc.Included = b.included
+	}
+
+	meta := Meta{}
+	for key, value := range resp.Metadata {
+		meta[key] = value
+	}
+	if resp.Page != nil && code >= 200 && code <= 299 {
+		meta["pagination"] = resp.Page.meta()
+	}
+	if len(meta) > 0 {
+		doc.Meta = meta
+	}
+
+	if resp.Request != nil && resp.Request.URL != nil {
+		doc.Links = map[string]string{"self": resp.Request.URL.RequestURI()}
+		if resp.Page != nil && code >= 200 && code <= 299 {
+			for _, l := range resp.Page.links(resp.Request.URL) {
+				doc.Links[l.rel] = l.href
+			}
+		}
+	}
+
+	return doc
+}
+
+// MarshalJSON encodes the document. Data is always present unless the
+// document holds errors.
+func (d JSONAPIDocument) MarshalJSON() ([]byte, error) {
+	var data *interface{}
+	if len(d.Errors) == 0 {
+		data = &d.Data
+	}
+
+	return json.Marshal(struct {
+		Data     *interface{}      `json:"data,omitempty"`
+		Errors   []*JSONAPIError   `json:"errors,omitempty"`
+		Included []*Resource       `json:"included,omitempty"`
+		Meta     Meta              `json:"meta,omitempty"`
+		Links    map[string]string `json:"links,omitempty"`
+	}{data, d.Errors, d.Included, d.Meta, d.Links})
+}
+
+// mediaType sends JSON encoded documents with the JSON:API media type
+func (JSONAPIDocument) mediaType(contentType string) string {
+	if baseMediaType(contentType) == "application/json" {
+		return jsonapiMediaType
+	}
+	return contentType
+}
+
+// jsonapiErrors converts an error body into error objects
+func jsonapiErrors(code int, v interface{}) []*JSONAPIError {
+	var errs []*JSONAPIError
+	switch e := v.(type) {
+	case jsonapiErrorer:
+		errs = e.jsonapiErrors(code)
+	case JSONAPIError:
+		errs = []*JSONAPIError{&e}
+	case *JSONAPIError:
+		errs = []*JSONAPIError{e}
+	case []*JSONAPIError:
+		errs = e
+	case []JSONAPIError:
+		for i := range e {
+			errs = append(errs, &e[i])
+		}
+	default:
+		errs = []*JSONAPIError{{Title: http.StatusText(code), Meta: v}}
+	}
+
+	status := strconv.Itoa(code)
+	converted := make([]*JSONAPIError, len(errs))
+	for i, e := range errs {
+		copied := *e
+		if copied.Status == "" {
+			copied.Status = status
+		}
+		converted[i] = &copied
+	}
+	return converted
+}
+
+func (m DefaultMessageResponse) jsonapiErrors(int) []*JSONAPIError {
+	return []*JSONAPIError{{
+		Status: strconv.Itoa(m.Status),
+		Title:  m.Message,
+	}}
+}
+
+func (p Problem) jsonapiErrors(int) []*JSONAPIError {
+	e := &JSONAPIError{
+		Title:  p.Title,
+		Detail: p.Detail,
+	}
+	if p.Status != 0 {
+		e.Status = strconv.Itoa(p.Status)
+	}
+	if p.Type != "" || p.Instance != "" {
+		e.Links = make(map[string]string)
+		if p.Type != "" {
+			e.Links["type"] = p.Type
+		}
+		if p.Instance != "" {
+			e.Links["about"] = p.Instance
+		}
+	}
+	if len(p.Extensions) > 0 {
+		e.Meta = p.Extensions
+	}
+	return []*JSONAPIError{e}
+}
+
+// resourceBuilder converts tagged structs into resource objects, collecting
+// the related resources to include
+type resourceBuilder struct {
+	included []*Resource
+	seen     map[ResourceIdentifier]bool
+}
+
+// data converts a resource or a slice of resources into resource objects.
+// Any other value is returned as it is.
+func (b *resourceBuilder) data(v interface{}) interface{} {
+	rv := indirect(reflect.ValueOf(v))
+	if !rv.IsValid() {
+		return nil
+	}
+
+	switch rv.Kind() {
+	case reflect.Struct:
+		if !isResourceType(rv.Type()) {
+			return v
+		}
+		b.seen[resourceIdentifier(rv)] = true
+		return b.resource(rv)
+
+	case reflect.Slice, reflect.Array:
+		if !isResourceType(indirectType(rv.Type().Elem())) {
+			return v
+		}
+		var elems []reflect.Value
+		for i := 0; i < rv.Len(); i++ {
+			if elem := indirect(rv.Index(i)); elem.IsValid() {
+				b.seen[resourceIdentifier(elem)] = true
+				elems = append(elems, elem)
+			}
+		}
+		resources := make([]*Resource, 0, len(elems))
+		for _, elem := range elems {
+			resources = append(resources, b.resource(elem))
+		}
+		return resources
+	}
+
+	return v
+}
+
+// resource converts a tagged struct into a resource object
+func (b *resourceBuilder) resource(rv reflect.Value) *Resource {
+	id := resourceIdentifier(rv)
+	res := &Resource{Type: id.Type, ID: id.ID}
+
+	t := rv.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+		kind, name, omitEmpty := parseJSONAPITag(field)
+		fv := rv.Field(i)
+
+		switch kind {
+		case "attr":
+			if omitEmpty && isEmptyValue(fv) {
+				continue
+			}
+			if res.Attributes == nil {
+				res.Attributes = make(map[string]interface{})
+			}
+			res.Attributes[name] = fv.Interface()
+
+		case "relation":
+			rel, ok := b.relationship(fv)
+			if !ok {
+				continue
+			}
+			if res.Relationships == nil {
+				res.Relationships = make(map[string]*Relationship)
+			}
+			res.Relationships[name] = rel
+		}
+	}
+
+	return res
+}
+
+// relationship converts a relation field into a relationship object, adding
+// the related resources to the included resources
+func (b *resourceBuilder) relationship(fv reflect.Value) (*Relationship, bool) {
+	t := indirectType(fv.Type())
+	switch {
+	case isResourceType(t):
+		related := indirect(fv)
+		if !related.IsValid() {
+			return &Relationship{}, true
+		}
+		return &Relationship{Data: b.include(related)}, true
+
+	case (t.Kind() == reflect.Slice || t.Kind() == reflect.Array) && isResourceType(indirectType(t.Elem())):
+		ids := []ResourceIdentifier{}
+		slice := indirect(fv)
+		if slice.IsValid() {
+			for i := 0; i < slice.Len(); i++ {
+				if related := indirect(slice.Index(i)); related.IsValid() {
+					ids = append(ids, b.include(related))
+				}
+			}
+		}
+		return &Relationship{Data: ids}, true
+	}
+
+	return nil, false
+}
+
+// include adds a related resource to the included resources, unless it is
+// already part of the document, and returns its identifier
+func (b *resourceBuilder) include(rv reflect.Value) ResourceIdentifier {
+	id := resourceIdentifier(rv)
+	if !b.seen[id] {
+		b.seen[id] = true
+		b.included = append(b.included, b.resource(rv))
+	}
+	return id
+}
+
+// parseJSONAPITag returns the kind and name of a field from its jsonapi tag,
+// and whether it is omitted when empty. The name of a primary field is the
+// resource type.
+func parseJSONAPITag(field reflect.StructField) (kind, name string, omitEmpty bool) {
+	tag := field.Tag.Get("jsonapi")
+	if tag == "" || tag == "-" {
+		return "", "", false
+	}
+
+	parts := strings.Split(tag, ",")
+	kind, name = parts[0], field.Name
+	if len(parts) > 1 && parts[1] != "" {
+		name = parts[1]
+	}
+	for _, opt := range parts[2:] {
+		if opt == "omitempty" {
+			omitEmpty = true
+		}
+	}
+	return kind, name, omitEmpty
+}
+
+// isResourceType reports whether t is a struct with a primary field
+func isResourceType(t reflect.Type) bool {
+	if t.Kind() != reflect.Struct {
+		return false
+	}
+	for i := 0; i < t.NumField(); i++ {
+		if kind, _, _ := parseJSONAPITag(t.Field(i)); kind == "primary" {
+			return true
+		}
+	}
+	return false
+}
+
+// resourceIdentifier returns the type and ID of a tagged struct
+func resourceIdentifier(rv reflect.Value) ResourceIdentifier {
+	t := rv.Type()
+	for i := 0; i < t.NumField(); i++ {
+		kind, name, _ := parseJSONAPITag(t.Field(i))
+		if kind != "primary" {
+			continue
+		}
+
+		id := ResourceIdentifier{Type: name}
+		fv := indirect(rv.Field(i))
+		switch {
+		case !fv.IsValid():
+		case fv.Kind() == reflect.String:
+			id.ID = fv.String()
+		case fv.Kind() >= reflect.Int && fv.Kind() <= reflect.Int64:
+			id.ID = strconv.FormatInt(fv.Int(), 10)
+		case fv.Kind() >= reflect.Uint && fv.Kind() <= reflect.Uintptr:
+			id.ID = strconv.FormatUint(fv.Uint(), 10)
+		default:
+			id.ID = fmt.Sprint(fv.Interface())
+		}
+		return id
+	}
+	return ResourceIdentifier{}
+}
+
+// indirect follows pointers and interfaces, returning the zero Value for nil
+func indirect(rv reflect.Value) reflect.Value {
+	for rv.Kind() == reflect.Ptr || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			return reflect.Value{}
+		}
+		rv = rv.Elem()
+	}
+	return rv
+}
+
+// indirectType follows pointer types
+func indirectType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t
+}
+
+// isEmptyValue reports whether v is empty in the sense of json's omitempty
+func isEmptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool:
+		return !v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int() == 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return v.Uint() == 0
+	case reflect.Float32, reflect.Float64:
+		return v.Float() == 0
+	case reflect.Interface, reflect.Ptr:
+		return v.IsNil()
+	}
+	return false
+}
@@ -0,0 +1,209 @@
+package respond
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type person struct {
+	ID   string `jsonapi:"primary,people"`
+	Name string `jsonapi:"attr,name"`
+}
+
+type comment struct {
+	ID     uint    `jsonapi:"primary,comments"`
+	Body   string  `jsonapi:"attr,body"`
+	Author *person `jsonapi:"relation,author"`
+}
+
+type article struct {
+	ID       int        `jsonapi:"primary,articles"`
+	Title    string     `jsonapi:"attr,title"`
+	Body     string     `jsonapi:"attr,body,omitempty"`
+	Author   *person    `jsonapi:"relation,author"`
+	Comments []*comment `jsonapi:"relation,comments"`
+	internal string
+}
+
+var jsonapiData = []struct {
+	testName string
+
+	respond func(resp *Response) error
+
+	expectedStatus int
+	expectedBody   string
+}{
+	{"resource",
+		func(resp *Response) error {
+			return resp.Ok(&article{ID: 1, Title: "Hello", Author: &person{"9", "Billy"}})
+		},
+		http.StatusOK,
+		`{"data":{"type":"articles","id":"1","attributes":{"title":"Hello"},` +
+			`"relationships":{"author":{"data":{"type":"people","id":"9"}},"comments":{"data":[]}}},` +
+			`"included":[{"type":"people","id":"9","attributes":{"name":"Billy"}}],` +
+			`"links":{"self":"/"}}`},
+	{"collection with shared relations",
+		func(resp *Response) error {
+			billy := &person{"9", "Billy"}
+			return resp.Ok([]article{
+				{ID: 1, Title: "One", Author: billy, Comments: []*comment{{5, "Nice", billy}}},
+				{ID: 2, Title: "Two", Body: "Text"},
+			})
+		},
+		http.StatusOK,
+		`{"data":[{"type":"articles","id":"1","attributes":{"title":"One"},` +
+			`"relationships":{"author":{"data":{"type":"people","id":"9"}},"comments":{"data":[{"type":"comments","id":"5"}]}}},` +
+			`{"type":"articles","id":"2","attributes":{"body":"Text","title":"Two"},` +
+			`"relationships":{"author":{"data":null},"comments":{"data":[]}}}],` +
+			`"included":[{"type":"people","id":"9","attributes":{"name":"Billy"}},` +
+			`{"type":"comments","id":"5","attributes":{"body":"Nice"},"relationships":{"author":{"data":{"type":"people","id":"9"}}}}],` +
+			`"links":{"self":"/"}}`},
+	{"untagged data",
+		func(resp *Response) error { return resp.Ok(map[string]int{"count": 3}) },
+		http.StatusOK, `{"data":{"count":3},"links":{"self":"/"}}`},
+	{"default message",
+		func(resp *Response) error { return resp.DefaultMessage().NotFound(nil) },
+		http.StatusNotFound, `{"errors":[{"status":"404","title":"Not Found"}],"links":{"self":"/"}}`},
+	{"problem",
+		func(resp *Response) error {
+			return resp.Problem(NewProblem(http.StatusConflict, "Title is taken").With("title", "Hello"))
+		},
+		http.StatusConflict,
+		`{"errors":[{"status":"409","title":"Conflict","detail":"Title is taken","meta":{"title":"Hello"}}],"links":{"self":"/"}}`},
+	{"error objects",
+		func(resp *Response) error {
+			return resp.UnprocessableEntity([]JSONAPIError{{
+				Code:   "required",
+				Title:  "Missing attribute",
+				Source: &JSONAPIErrorSource{Pointer: "/data/attributes/title"},
+			}})
+		},
+		http.StatusUnprocessableEntity,
+		`{"errors":[{"status":"422","code":"required","title":"Missing attribute",` +
+			`"source":{"pointer":"/data/attributes/title"}}],"links":{"self":"/"}}`},
+	{"other error",
+		func(resp *Response) error { return resp.BadRequest(&Error{400, "Invalid"}) },
+		http.StatusBadRequest,
+		`{"errors":[{"status":"400","title":"Bad Request","meta":{"code":400,"message":"Invalid"}}],"links":{"self":"/"}}`},
+}
+
+func TestJSONAPI(t *testing.T) {
+	for _, datum := range jsonapiData {
+		datum := datum
+		t.Run(datum.testName, func(t *testing.T) {
+			t.Parallel()
+
+			req := newRequest(t, "GET")
+
+			rr := httptest.NewRecorder()
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				datum.respond(NewResponse(w).WithRequest(r).JSONAPI())
+			})
+			handler.ServeHTTP(rr, req)
+
+			if err := validateStatusCode(rr.Code, datum.expectedStatus); err != nil {
+				t.Fatal(err)
+			}
+
+			if err := validateResponseHeader(rr.Header().Get("Content-Type"), "application/vnd.api+json"); err != nil {
+				t.Fatal(err)
+			}
+
+			if err := validateResponseBody(rr.Body.String(), datum.expectedBody); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestJSONAPINegotiation(t *testing.T) {
+	t.Parallel()
+
+	req := newRequest(t, "GET")
+	req.Header.Set("Accept", "application/vnd.api+json")
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		NewResponse(w).WithRequest(r).JSONAPI().Ok(&person{"9", "Billy"})
+	})
+	handler.ServeHTTP(rr, req)
+
+	if err := validateStatusCode(rr.Code, http.StatusOK); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := validateResponseHeader(rr.Header().Get("Content-Type"), "application/vnd.api+json"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestJSONAPIOtherAccept(t *testing.T) {
+	t.Parallel()
+
+	req := newRequest(t, "GET")
+	req.Header.Set("Accept", "application/xml")
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		NewResponse(w).WithRequest(r).AddEncoder(XMLEncoder{}).JSONAPI().Ok(&person{"9", "Billy"})
+	})
+	handler.ServeHTTP(rr, req)
+
+	if err := validateStatusCode(rr.Code, http.StatusOK); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := validateResponseHeader(rr.Header().Get("Content-Type"), "application/vnd.api+json"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestJSONAPIFallbackUnformatted(t *testing.T) {
+	t.Parallel()
+
+	req := newRequest(t, "GET")
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		NewResponse(w).WithRequest(r).JSONAPI().WithEncoder(XMLEncoder{}).Ok(&person{"9", "Billy"})
+	})
+	handler.ServeHTTP(rr, req)
+
+	if err := validateStatusCode(rr.Code, http.StatusInternalServerError); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `<?xml version="1.0" encoding="UTF-8"?>` + "\n" +
+		`<response><status>500</status><message>Internal Server Error</message></response>`
+	if err := validateResponseBody(rr.Body.String(), expected); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestJSONAPIPagination(t *testing.T) {
+	t.Parallel()
+
+	req, err := http.NewRequest("GET", "/people?limit=1", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		NewResponse(w).
+			WithRequest(r).
+			JSONAPI().
+			Paginate(OffsetPage(0, 1, 2)).
+			Ok([]person{{"9", "Billy"}})
+	})
+	handler.ServeHTTP(rr, req)
+
+	expected := `{"data":[{"type":"people","id":"9","attributes":{"name":"Billy"}}],` +
+		`"meta":{"pagination":{"limit":1,"offset":0,"total":2}},` +
+		`"links":{"first":"/people?limit=1\u0026offset=0","last":"/people?limit=1\u0026offset=1",` +
+		`"next":"/people?limit=1\u0026offset=1","self":"/people?limit=1"}}`
+	if err := validateResponseBody(rr.Body.String(), expected); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,106 @@
+package respond
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+)
+
+// defaultCallbackParam is the query parameter naming the JSONP callback when
+// none is given
+const defaultCallbackParam = "callback"
+
+// maxCallbackLength is the maximum length of a JSONP callback name
+const maxCallbackLength = 128
+
+// jsonpContentType is the content type of JSONP responses
+const jsonpContentType = "application/javascript; charset=utf-8"
+
+// ErrInvalidCallback is returned when the JSONP callback of a request is not
+// a safe JavaScript identifier. The request is answered with a 400.
+var ErrInvalidCallback = errors.New("respond: invalid JSONP callback")
+
+// reservedWords are the JavaScript reserved words, which cannot be used as
+// callback names
+var reservedWords = map[string]bool{
+	"await": true, "break": true, "case": true, "catch": true, "class": true, "const": true,
+	"continue": true, "debugger": true, "default": true, "delete": true, "do": true, "else": true,
+	"enum": true, "export": true, "extends": true, "false": true, "finally": true, "for": true,
+	"function": true, "if": true, "implements": true, "import": true, "in": true, "instanceof": true,
+	"interface": true, "let": true, "new": true, "null": true, "package": true, "private": true,
+	"protected": true, "public": true, "return": true, "static": true, "super": true, "switch": true,
+	"this": true, "throw": true, "true": true, "try": true, "typeof": true, "var": true, "void": true,
+	"while": true, "with": true, "yield": true,
+}
+
+// JSONP wraps JSON bodies in a call to the function named by the request's
+// param query parameter, or callback when param is empty. Callbacks must be
+// JavaScript identifiers, optionally dotted, e.g. jQuery123.handle. Requests
+// without the parameter are answered with plain JSON.
+func (resp *Response) JSONP(param string) *Response {
+	if param == "" {
+		param = defaultCallbackParam
+	}
+	resp.CallbackParam = param
+	return resp
+}
+
+// jsonpCallback returns the callback that bodies encoded by enc are wrapped
+// in, or an empty string when they are not wrapped
+func (resp *Response) jsonpCallback(enc Encoder) (string, error) {
+	if resp.CallbackParam == "" || resp.Request == nil || resp.Request.URL == nil {
+		return "", nil
+	}
+	if !isJSONMediaType(enc.ContentType()) {
+		return "", nil
+	}
+
+	values, ok := resp.Request.URL.Query()[resp.CallbackParam]
+	if !ok {
+		return "", nil
+	}
+	if len(values) != 1 || !validCallback(values[0]) {
+		return "", ErrInvalidCallback
+	}
+	return values[0], nil
+}
+
+// validCallback reports whether name is a dotted path of JavaScript
+// identifiers that are not reserved words
+func validCallback(name string) bool {
+	if name == "" || len(name) > maxCallbackLength {
+		return false
+	}
+
+	for _, ident := range strings.Split(name, ".") {
+		if ident == "" || reservedWords[ident] {
+			return false
+		}
+		for i, c := range ident {
+			switch {
+			case c == '_' || c == '$':
+			case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z':
+			case c >= '0' && c <= '9' && i > 0:
+			default:
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// wrapJSONP wraps the body in a call to callback. The leading comment stops
+// the response from being interpreted as another content type, and line
+// separators, which are invalid in older JavaScript string literals, are
+// escaped.
+func wrapJSONP(callback string, body []byte) []byte {
+	body = bytes.Replace(body, []byte("\u2028"), []byte(`\u2028`), -1)
+	body = bytes.Replace(body, []byte("\u2029"), []byte(`\u2029`), -1)
+
+	wrapped := make([]byte, 0, len(body)+len(callback)+8)
+	wrapped = append(wrapped, "/**/ "...)
+	wrapped = append(wrapped, callback...)
+	wrapped = append(wrapped, '(')
+	wrapped = append(wrapped, body...)
+	return append(wrapped, ");"...)
+}
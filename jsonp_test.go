@@ -0,0 +1,152 @@
+package respond
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+var callbackData = []struct {
+	callback string
+	valid    bool
+}{
+	{"handle", true},
+	{"jQuery123_456.handle", true},
+	{"$._cb", true},
+	{"", false},
+	{"1handle", false},
+	{"handle.", false},
+	{"a..b", false},
+	{"alert(1)", false},
+	{"handle;alert", false},
+	{"window.function", false},
+	{"<script>", false},
+	{"héllo", false},
+	{strings.Repeat("a", 129), false},
+}
+
+func TestValidCallback(t *testing.T) {
+	for _, datum := range callbackData {
+		if got := validCallback(datum.callback); got != datum.valid {
+			t.Errorf("validCallback(%q) = %v, wanted %v", datum.callback, got, datum.valid)
+		}
+	}
+}
+
+var jsonpData = []struct {
+	testName string
+
+	url     string
+	respond func(resp *Response) error
+
+	expectedStatus      int
+	expectedContentType string
+	expectedNosniff     string
+	expectedBody        string
+}{
+	{"wrapped", "/?callback=app.handle",
+		func(resp *Response) error { return resp.Ok(&User{1, "Billy", "billy@example.com"}) },
+		http.StatusOK, "application/javascript; charset=utf-8", "nosniff",
+		`/**/ app.handle({"id":1,"name":"Billy","email":"billy@example.com"});`},
+	{"no callback", "/",
+		func(resp *Response) error { return resp.Ok(&User{1, "Billy", "billy@example.com"}) },
+		http.StatusOK, "application/json; charset=utf-8", "",
+		`{"id":1,"name":"Billy","email":"billy@example.com"}`},
+	{"error", "/?callback=handle",
+		func(resp *Response) error { return resp.DefaultMessage().NotFound(nil) },
+		http.StatusNotFound, "application/javascript; charset=utf-8", "nosniff",
+		`/**/ handle({"status":404,"message":"Not Found"});`},
+	{"line separators", "/?callback=handle",
+		func(resp *Response) error { return resp.WithEncoder(rawJSONEncoder).Ok([]string{}) },
+		http.StatusOK, "application/javascript; charset=utf-8", "nosniff",
+		`/**/ handle(["a\u2028b\u2029c"]);`},
+	{"invalid callback", "/?callback=alert(document.cookie)",
+		func(resp *Response) error { return resp.DefaultMessage().Ok(nil) },
+		http.StatusBadRequest, "application/json; charset=utf-8", "",
+		`{"status":400,"message":"Bad Request"}`},
+	{"no body", "/?callback=handle",
+		func(resp *Response) error { return resp.NoContent() },
+		http.StatusNoContent, "application/json; charset=utf-8", "",
+		``},
+}
+
+// rawJSONEncoder sends raw line separators, which json.Marshal would escape
+var rawJSONEncoder = NewEncoder("application/json", func(v interface{}) ([]byte, error) {
+	return []byte("[\"a\u2028b\u2029c\"]"), nil
+})
+
+func TestJSONP(t *testing.T) {
+	for _, datum := range jsonpData {
+		datum := datum
+		t.Run(datum.testName, func(t *testing.T) {
+			t.Parallel()
+
+			req, err := http.NewRequest("GET", datum.url, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			rr := httptest.NewRecorder()
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				datum.respond(NewResponse(w).WithRequest(r).JSONP(""))
+			})
+			handler.ServeHTTP(rr, req)
+
+			if err := validateStatusCode(rr.Code, datum.expectedStatus); err != nil {
+				t.Fatal(err)
+			}
+
+			if err := validateResponseHeader(rr.Header().Get("Content-Type"), datum.expectedContentType); err != nil {
+				t.Fatal(err)
+			}
+
+			if err := validateResponseHeader(rr.Header().Get("X-Content-Type-Options"), datum.expectedNosniff); err != nil {
+				t.Fatal(err)
+			}
+
+			if err := validateResponseBody(rr.Body.String(), datum.expectedBody); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestJSONPInvalidCallbackError(t *testing.T) {
+	t.Parallel()
+
+	req, err := http.NewRequest("GET", "/?cb=1bad", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var respErr error
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		respErr = NewResponse(w).WithRequest(r).JSONP("cb").Ok(&User{1, "Billy", "billy@example.com"})
+	})
+	handler.ServeHTTP(rr, req)
+
+	if respErr != ErrInvalidCallback {
+		t.Fatalf("expected ErrInvalidCallback, got %v", respErr)
+	}
+}
+
+func TestJSONPNotEnabled(t *testing.T) {
+	t.Parallel()
+
+	req, err := http.NewRequest("GET", "/?callback=handle", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		NewResponse(w).WithRequest(r).Ok(&User{1, "Billy", "billy@example.com"})
+	})
+	handler.ServeHTTP(rr, req)
+
+	if err := validateResponseBody(rr.Body.String(), `{"id":1,"name":"Billy","email":"billy@example.com"}`); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,143 @@
+package respond
+
+import (
+	"mime"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// acceptValue is a single element of an Accept style header
+type acceptValue struct {
+	value  string
+	params map[string]string
+	q      float64
+}
+
+// parseAccept parses a comma separated Accept style header, such as Accept or
+// Accept-Encoding, into its values. Values with a malformed q parameter are
+// ignored. The result is ordered by descending quality, keeping header order
+// for values of equal quality.
+func parseAccept(header string) []acceptValue {
+	var values []acceptValue
+
+	for _, part := range strings.Split(header, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		fields := strings.Split(part, ";")
+		av := acceptValue{
+			value: strings.ToLower(strings.TrimSpace(fields[0])),
+			q:     1,
+		}
+
+		valid := true
+		for _, param := range fields[1:] {
+			kv := strings.SplitN(param, "=", 2)
+			key := strings.ToLower(strings.TrimSpace(kv[0]))
+			val := ""
+			if len(kv) == 2 {
+				val = strings.Trim(strings.TrimSpace(kv[1]), `"`)
+			}
+
+			if key == "q" {
+				q, err := strconv.ParseFloat(val, 64)
+				if err != nil || q < 0 || q > 1 {
+					valid = false
+					break
+				}
+				av.q = q
+				continue
+			}
+
+			if av.params == nil {
+				av.params = make(map[string]string)
+			}
+			av.params[key] = val
+		}
+
+		if valid && av.value != "" {
+			values = append(values, av)
+		}
+	}
+
+	sort.SliceStable(values, func(i, j int) bool {
+		return values[i].q > values[j].q
+	})
+
+	return values
+}
+
+// mediaRangeSpecificity reports how specifically the media range accepts
+// mediaType, or -1 if it does not accept it at all
+func mediaRangeSpecificity(mediaRange, mediaType string) int {
+	if mediaRange == "*/*" {
+		return 0
+	}
+
+	rangeType, rangeSub := splitMediaType(mediaRange)
+	typ, sub := splitMediaType(mediaType)
+	if rangeType != typ {
+		return -1
+	}
+	if rangeSub == "*" {
+		return 1
+	}
+	if rangeSub != sub {
+		return -1
+	}
+	return 2
+}
+
+func splitMediaType(mediaType string) (string, string) {
+	parts := strings.SplitN(mediaType, "/", 2)
+	if len(parts) != 2 {
+		return parts[0], ""
+	}
+	return parts[0], parts[1]
+}
+
+// baseMediaType strips any parameters from a content type
+func baseMediaType(contentType string) string {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return strings.ToLower(strings.TrimSpace(strings.SplitN(contentType, ";", 2)[0]))
+	}
+	return mediaType
+}
+
+// negotiate picks the encoder best matching the given Accept header. Each
+// encoder takes the quality of the most specific media range matching its
+// content type; the encoder with the highest non-zero quality wins, ties going
+// to the encoder registered first. An empty header accepts the first encoder.
+func negotiate(accept string, encoders []Encoder) (Encoder, bool) {
+	if len(encoders) == 0 {
+		return nil, false
+	}
+	if strings.TrimSpace(accept) == "" {
+		return encoders[0], true
+	}
+
+	ranges := parseAccept(accept)
+
+	var best Encoder
+	bestQ := 0.0
+	for _, enc := range encoders {
+		mediaType := baseMediaType(enc.ContentType())
+
+		q, specificity := 0.0, -1
+		for _, r := range ranges {
+			if s := mediaRangeSpecificity(r.value, mediaType); s > specificity {
+				q, specificity = r.q, s
+			}
+		}
+
+		if q > bestQ {
+			best, bestQ = enc, q
+		}
+	}
+
+	return best, best != nil
+}
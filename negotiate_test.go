@@ -0,0 +1,112 @@
+package respond
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+var textEncoder = NewEncoder("text/plain; charset=utf-8", func(v interface{}) ([]byte, error) {
+	return []byte("text"), nil
+})
+
+var negotiateData = []struct {
+	testName string
+
+	accept string
+
+	expectedContentType string
+	expectedOk          bool
+}{
+	{"no accept header", "", "application/json; charset=utf-8", true},
+	{"exact match", "text/plain", "text/plain; charset=utf-8", true},
+	{"wildcard", "*/*", "application/json; charset=utf-8", true},
+	{"subtype wildcard", "text/*", "text/plain; charset=utf-8", true},
+	{"q-values", "application/json;q=0.5, text/plain;q=0.8", "text/plain; charset=utf-8", true},
+	{"specific range overrides wildcard", "*/*;q=0.9, application/json;q=0.1", "text/plain; charset=utf-8", true},
+	{"excluded by q=0", "application/json;q=0, */*", "text/plain; charset=utf-8", true},
+	{"case insensitive", "Text/Plain", "text/plain; charset=utf-8", true},
+	{"no match", "image/png", "", false},
+	{"malformed q ignored", "text/plain;q=abc, application/json", "application/json; charset=utf-8", true},
+}
+
+func TestNegotiate(t *testing.T) {
+	encoders := []Encoder{JSONEncoder{}, textEncoder}
+
+	for _, datum := range negotiateData {
+		datum := datum
+		t.Run(datum.testName, func(t *testing.T) {
+			t.Parallel()
+
+			enc, ok := negotiate(datum.accept, encoders)
+			if ok != datum.expectedOk {
+				t.Fatalf("negotiate returned ok %v wanted %v", ok, datum.expectedOk)
+			}
+			if !ok {
+				return
+			}
+
+			if err := validateResponseHeader(enc.ContentType(), datum.expectedContentType); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestNegotiatedResponse(t *testing.T) {
+	t.Parallel()
+
+	req := newRequest(t, "GET")
+	req.Header.Set("Accept", "text/plain")
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		NewResponse(w).
+			WithRequest(r).
+			AddEncoder(textEncoder).
+			Ok(&User{1, "Billy", "billy@example.com"})
+	})
+	handler.ServeHTTP(rr, req)
+
+	if err := validateResponseHeader(rr.Header().Get("Content-Type"), "text/plain; charset=utf-8"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := validateResponseHeader(rr.Header().Get("Vary"), "Accept"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := validateResponseBody(rr.Body.String(), "text"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestNotAcceptableResponse(t *testing.T) {
+	t.Parallel()
+
+	req := newRequest(t, "GET")
+	req.Header.Set("Accept", "image/png")
+
+	var respErr error
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		respErr = NewResponse(w).
+			WithRequest(r).
+			DefaultMessage().
+			Ok(&User{1, "Billy", "billy@example.com"})
+	})
+	handler.ServeHTTP(rr, req)
+
+	if respErr != ErrNotAcceptable {
+		t.Fatalf("expected ErrNotAcceptable, got %v", respErr)
+	}
+
+	if err := validateStatusCode(rr.Code, http.StatusNotAcceptable); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `{"status":406,"message":"Not Acceptable"}`
+	if err := validateResponseBody(rr.Body.String(), expected); err != nil {
+		t.Fatal(err)
+	}
+}
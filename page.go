@@ -0,0 +1,219 @@
+package respond
+
+import (
+	"encoding/xml"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Query parameters used in the links to other pages
+const (
+	offsetParam = "offset"
+	limitParam  = "limit"
+	cursorParam = "cursor"
+)
+
+// Page describes the page of a collection a response holds. Create pages
+// with OffsetPage or CursorPage.
+type Page struct {
+	// Limit is the maximum number of items in a page
+	Limit int
+	// Offset is the position of the first item of an offset page
+	Offset int
+	// Total is the number of items in the collection, or -1 if unknown
+	Total int
+
+	// Cursor identifies a cursor page, and is empty for the first page.
+	// Next and Prev are the cursors of the adjacent pages, and are empty
+	// when there is no such page.
+	Cursor string
+	Next   string
+	Prev   string
+
+	// TotalHeader sends the total in the X-Total-Count header
+	TotalHeader bool
+	// Meta wraps the body as {"data": ..., "meta": {"pagination": ...}}
+	Meta bool
+
+	cursorBased bool
+}
+
+// OffsetPage creates a page of up to limit items starting at offset, in a
+// collection of total items. A total of -1 means the size of the collection
+// is unknown, in which case a next link is always given.
+func OffsetPage(offset, limit, total int) *Page {
+	return &Page{
+		Limit:  limit,
+		Offset: offset,
+		Total:  total,
+	}
+}
+
+// CursorPage creates a page of up to limit items identified by cursor,
+// where next and prev are the cursors of the adjacent pages
+func CursorPage(limit int, cursor, next, prev string) *Page {
+	return &Page{
+		Limit:       limit,
+		Total:       -1,
+		Cursor:      cursor,
+		Next:        next,
+		Prev:        prev,
+		cursorBased: true,
+	}
+}
+
+// WithTotalHeader sends the total in the X-Total-Count header
+func (p *Page) WithTotalHeader() *Page {
+	p.TotalHeader = true
+	return p
+}
+
+// WithMeta includes the pagination in the body
+func (p *Page) WithMeta() *Page {
+	p.Meta = true
+	return p
+}
+
+// Paginate describes the page of a collection held by successful responses.
+// Links to the other pages are sent in the Link header, built from the
+// request URL when it is set with WithRequest.
+func (resp *Response) Paginate(p *Page) *Response {
+	resp.Page = p
+	return resp
+}
+
+// PageMeta is the pagination member of the meta block of a paged body
+type PageMeta struct {
+	Limit  int    `json:"limit" xml:"limit"`
+	Offset *int   `json:"offset,omitempty" xml:"offset,omitempty"`
+	Total  *int   `json:"total,omitempty" xml:"total,omitempty"`
+	Cursor string `json:"cursor,omitempty" xml:"cursor,omitempty"`
+	Next   string `json:"next_cursor,omitempty" xml:"next_cursor,omitempty"`
+	Prev   string `json:"prev_cursor,omitempty" xml:"prev_cursor,omitempty"`
+}
+
+// meta returns the description of the page sent in the body
+func (p *Page) meta() PageMeta {
+	meta := PageMeta{Limit: p.Limit}
+	if p.cursorBased {
+		meta.Cursor, meta.Next, meta.Prev = p.Cursor, p.Next, p.Prev
+	} else {
+		offset := p.Offset
+		meta.Offset = &offset
+	}
+	if p.Total >= 0 {
+		total := p.Total
+		meta.Total = &total
+	}
+	return meta
+}
+
+type pagedBody struct {
+	XMLName xml.Name    `json:"-" xml:"response"`
+	Data    interface{} `json:"data" xml:"data"`
+	Meta    struct {
+		Pagination PageMeta `json:"pagination" xml:"pagination"`
+	} `json:"meta" xml:"meta"`
+}
+
+// pageBody wraps a successful body with the page meta, when enabled
+func (resp *Response) pageBody(code int, v interface{}) interface{} {
+	if resp.Page == nil || !resp.Page.Meta || v == nil || code < 200 || code > 299 {
+		return v
+	}
+
+	body := pagedBody{Data: v}
+	body.Meta.Pagination = resp.Page.meta()
+	return body
+}
+
+// writePageHeaders sends the Link and X-Total-Count headers of the page
+func (resp *Response) writePageHeaders(header http.Header) {
+	p := resp.Page
+
+	if p.TotalHeader && p.Total >= 0 {
+		header.Set("X-Total-Count", strconv.Itoa(p.Total))
+	}
+
+	if resp.Request == nil || resp.Request.URL == nil {
+		return
+	}
+
+	var links []string
+	for _, l := range p.links(resp.Request.URL) {
+		links = append(links, "<"+l.href+`>; rel="`+l.rel+`"`)
+	}
+	if len(links) > 0 {
+		header.Add("Link", strings.Join(links, ", "))
+	}
+}
+
+type pageLink struct {
+	rel  string
+	href string
+}
+
+// links returns the links to the other pages of the collection, relative to
+// the URL of the current page
+func (p *Page) links(current *url.URL) []pageLink {
+	var links []pageLink
+	add := func(rel string, set map[string]string) {
+		u := *current
+		q := u.Query()
+		for key, value := range set {
+			if value == "" {
+				q.Del(key)
+			} else {
+				q.Set(key, value)
+			}
+		}
+		u.RawQuery = q.Encode()
+		links = append(links, pageLink{rel, u.RequestURI()})
+	}
+
+	limit := strconv.Itoa(p.Limit)
+
+	if p.cursorBased {
+		if p.Cursor != "" {
+			add("first", map[string]string{cursorParam: "", limitParam: limit})
+		}
+		if p.Prev != "" {
+			add("prev", map[string]string{cursorParam: p.Prev, limitParam: limit})
+		}
+		if p.Next != "" {
+			add("next", map[string]string{cursorParam: p.Next, limitParam: limit})
+		}
+		return links
+	}
+
+	if p.Limit <= 0 {
+		return nil
+	}
+
+	offsetLink := func(rel string, offset int) {
+		add(rel, map[string]string{offsetParam: strconv.Itoa(offset), limitParam: limit})
+	}
+
+	offsetLink("first", 0)
+	if p.Offset > 0 {
+		prev := p.Offset - p.Limit
+		if prev < 0 {
+			prev = 0
+		}
+		offsetLink("prev", prev)
+	}
+	if p.Total < 0 || p.Offset+p.Limit < p.Total {
+		offsetLink("next", p.Offset+p.Limit)
+	}
+	if p.Total >= 0 {
+		last := 0
+		if p.Total > 0 {
+			last = (p.Total - 1) / p.Limit * p.Limit
+		}
+		offsetLink("last", last)
+	}
+
+	return links
+}
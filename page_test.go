@@ -0,0 +1,121 @@
+package respond
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+var pageLinkData = []struct {
+	testName string
+
+	url  string
+	page *Page
+
+	expectedLink string
+}{
+	{"first page", "/users?limit=10&sort=name", OffsetPage(0, 10, 25),
+		`</users?limit=10&offset=0&sort=name>; rel="first", ` +
+			`</users?limit=10&offset=10&sort=name>; rel="next", ` +
+			`</users?limit=10&offset=20&sort=name>; rel="last"`},
+	{"middle page", "/users?offset=10&limit=10", OffsetPage(10, 10, 25),
+		`</users?limit=10&offset=0>; rel="first", ` +
+			`</users?limit=10&offset=0>; rel="prev", ` +
+			`</users?limit=10&offset=20>; rel="next", ` +
+			`</users?limit=10&offset=20>; rel="last"`},
+	{"last page", "/users?offset=20&limit=10", OffsetPage(20, 10, 25),
+		`</users?limit=10&offset=0>; rel="first", ` +
+			`</users?limit=10&offset=10>; rel="prev", ` +
+			`</users?limit=10&offset=20>; rel="last"`},
+	{"unknown total", "/users", OffsetPage(5, 10, -1),
+		`</users?limit=10&offset=0>; rel="first", ` +
+			`</users?limit=10&offset=0>; rel="prev", ` +
+			`</users?limit=10&offset=15>; rel="next"`},
+	{"first cursor page", "/users", CursorPage(10, "", "b", ""),
+		`</users?cursor=b&limit=10>; rel="next"`},
+	{"cursor page", "/users?cursor=b", CursorPage(10, "b", "c", "a"),
+		`</users?limit=10>; rel="first", ` +
+			`</users?cursor=a&limit=10>; rel="prev", ` +
+			`</users?cursor=c&limit=10>; rel="next"`},
+	{"single cursor page", "/users", CursorPage(10, "", "", ""), ""},
+}
+
+func TestPaginateLinks(t *testing.T) {
+	for _, datum := range pageLinkData {
+		datum := datum
+		t.Run(datum.testName, func(t *testing.T) {
+			t.Parallel()
+
+			req, err := http.NewRequest("GET", datum.url, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			rr := httptest.NewRecorder()
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				NewResponse(w).
+					WithRequest(r).
+					Paginate(datum.page).
+					Ok([]User{})
+			})
+			handler.ServeHTTP(rr, req)
+
+			if err := validateResponseHeader(rr.Header().Get("Link"), datum.expectedLink); err != nil {
+				t.Fatal(err)
+			}
+
+			if err := validateResponseBody(rr.Body.String(), "[]"); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestPaginateMeta(t *testing.T) {
+	t.Parallel()
+
+	req := newRequest(t, "GET")
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		NewResponse(w).
+			WithRequest(r).
+			Paginate(OffsetPage(0, 1, 2).WithTotalHeader().WithMeta()).
+			Ok([]User{{1, "Billy", "billy@example.com"}})
+	})
+	handler.ServeHTTP(rr, req)
+
+	if err := validateResponseHeader(rr.Header().Get("X-Total-Count"), "2"); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `{"data":[{"id":1,"name":"Billy","email":"billy@example.com"}],` +
+		`"meta":{"pagination":{"limit":1,"offset":0,"total":2}}}`
+	if err := validateResponseBody(rr.Body.String(), expected); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestPaginateError(t *testing.T) {
+	t.Parallel()
+
+	req := newRequest(t, "GET")
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		NewResponse(w).
+			WithRequest(r).
+			Paginate(OffsetPage(0, 1, 2).WithTotalHeader().WithMeta()).
+			BadRequest(&Error{400, "Invalid limit"})
+	})
+	handler.ServeHTTP(rr, req)
+
+	if err := validateResponseHeader(rr.Header().Get("Link"), ""); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `{"code":400,"message":"Invalid limit"}`
+	if err := validateResponseBody(rr.Body.String(), expected); err != nil {
+		t.Fatal(err)
+	}
+}
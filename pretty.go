@@ -0,0 +1,69 @@
+package respond
+
+import (
+	"strconv"
+	"strings"
+)
+
+// prettyParam is the query and Accept parameter requesting pretty-printed
+// bodies
+const prettyParam = "pretty"
+
+// prettyIndent is the indentation of pretty-printed bodies
+const prettyIndent = "  "
+
+// DefaultPretty pretty-prints the bodies of responses that have not been set
+// to with Pretty, when true
+var DefaultPretty bool
+
+// IndentEncoder is implemented by encoders that can pretty-print bodies
+type IndentEncoder interface {
+	Encoder
+	// EncodeIndent returns the encoded form of v, with each level of
+	// nesting indented
+	EncodeIndent(v interface{}, indent string) ([]byte, error)
+}
+
+// Pretty sets whether the body is pretty-printed, overriding DefaultPretty.
+// Requests can ask for pretty-printing with the pretty query parameter, e.g.
+// ?pretty or ?pretty=false, or with a pretty parameter of the accepted media
+// type, e.g. Accept: application/json; pretty=true. Bodies are only
+// pretty-printed by encoders implementing IndentEncoder.
+func (resp *Response) Pretty(enabled bool) *Response {
+	resp.PrettyPrint = &enabled
+	return resp
+}
+
+// pretty reports whether the body encoded by enc is pretty-printed
+func (resp *Response) pretty(enc Encoder) bool {
+	if resp.Request != nil {
+		if resp.Request.URL != nil {
+			if values, ok := resp.Request.URL.Query()[prettyParam]; ok {
+				return isTrue(values[0])
+			}
+		}
+
+		mediaType := baseMediaType(enc.ContentType())
+		for _, av := range parseAccept(strings.Join(resp.Request.Header["Accept"], ",")) {
+			value, ok := av.params[prettyParam]
+			if ok && mediaRangeSpecificity(av.value, mediaType) >= 0 {
+				return isTrue(value)
+			}
+		}
+	}
+
+	if resp.PrettyPrint != nil {
+		return *resp.PrettyPrint
+	}
+	return DefaultPretty
+}
+
+// isTrue reports whether a flag parameter is set, with an empty value
+// meaning true
+func isTrue(value string) bool {
+	if value == "" {
+		return true
+	}
+	b, err := strconv.ParseBool(value)
+	return err == nil && b
+}
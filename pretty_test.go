@@ -0,0 +1,128 @@
+package respond
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+const prettyUser = "{\n  \"id\": 1,\n  \"name\": \"Billy\",\n  \"email\": \"billy@example.com\"\n}"
+
+const compactUser = `{"id":1,"name":"Billy","email":"billy@example.com"}`
+
+var prettyData = []struct {
+	testName string
+
+	url    string
+	accept string
+	pretty *bool
+
+	expectedBody string
+}{
+	{"compact by default", "/", "", nil, compactUser},
+	{"response", "/", "", boolPtr(true), prettyUser},
+	{"query parameter", "/?pretty", "", nil, prettyUser},
+	{"query parameter true", "/?pretty=1", "", nil, prettyUser},
+	{"query parameter overrides response", "/?pretty=false", "", boolPtr(true), compactUser},
+	{"accept parameter", "/", "application/json; pretty=true", nil, prettyUser},
+	{"accept parameter of another type", "/", "text/html; pretty, application/json", nil, compactUser},
+	{"accept parameter of wildcard", "/", "*/*; pretty", nil, prettyUser},
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
+
+func TestPretty(t *testing.T) {
+	for _, datum := range prettyData {
+		datum := datum
+		t.Run(datum.testName, func(t *testing.T) {
+			t.Parallel()
+
+			req, err := http.NewRequest("GET", datum.url, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if datum.accept != "" {
+				req.Header.Set("Accept", datum.accept)
+			}
+
+			rr := httptest.NewRecorder()
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				resp := NewResponse(w).WithRequest(r)
+				if datum.pretty != nil {
+					resp.Pretty(*datum.pretty)
+				}
+				resp.Ok(&User{1, "Billy", "billy@example.com"})
+			})
+			handler.ServeHTTP(rr, req)
+
+			if err := validateResponseBody(rr.Body.String(), datum.expectedBody); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestPrettyDefaultMessage(t *testing.T) {
+	t.Parallel()
+
+	req := newRequest(t, "GET")
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		NewResponse(w).Pretty(true).DefaultMessage().NotFound(nil)
+	})
+	handler.ServeHTTP(rr, req)
+
+	expected := "{\n  \"status\": 404,\n  \"message\": \"Not Found\"\n}"
+	if err := validateResponseBody(rr.Body.String(), expected); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestPrettyXML(t *testing.T) {
+	t.Parallel()
+
+	req := newRequest(t, "GET")
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		NewResponse(w).WithEncoder(XMLEncoder{}).Pretty(true).DefaultMessage().Ok(nil)
+	})
+	handler.ServeHTTP(rr, req)
+
+	expected := `<?xml version="1.0" encoding="UTF-8"?>` + "\n" +
+		"<response>\n  <status>200</status>\n  <message>OK</message>\n</response>"
+	if err := validateResponseBody(rr.Body.String(), expected); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestDefaultPretty is not parallel as it changes the package default
+func TestDefaultPretty(t *testing.T) {
+	DefaultPretty = true
+	defer func() { DefaultPretty = false }()
+
+	req := newRequest(t, "GET")
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		NewResponse(w).Ok(&User{1, "Billy", "billy@example.com"})
+	})
+	handler.ServeHTTP(rr, req)
+
+	if err := validateResponseBody(rr.Body.String(), prettyUser); err != nil {
+		t.Fatal(err)
+	}
+
+	rr = httptest.NewRecorder()
+	handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		NewResponse(w).Pretty(false).Ok(&User{1, "Billy", "billy@example.com"})
+	})
+	handler.ServeHTTP(rr, req)
+
+	if err := validateResponseBody(rr.Body.String(), compactUser); err != nil {
+		t.Fatal(err)
+	}
+}
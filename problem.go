@@ -0,0 +1,185 @@
+package respond
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"net/http"
+	"sort"
+)
+
+// problemNamespace is the XML namespace of problem documents
+const problemNamespace = "urn:ietf:rfc:7807"
+
+// Problem is an RFC 9457 problem details document
+type Problem struct {
+	Type     string
+	Title    string
+	Status   int
+	Detail   string
+	Instance string
+
+	// Extensions holds additional members of the document. Members named
+	// after one of the standard members are ignored.
+	Extensions map[string]interface{}
+}
+
+// NewProblem creates a problem for the given status, titled with the
+// status text
+func NewProblem(status int, detail string) *Problem {
+	return &Problem{
+		Title:  http.StatusText(status),
+		Status: status,
+		Detail: detail,
+	}
+}
+
+// With sets an extension member of the problem
+func (p *Problem) With(key string, value interface{}) *Problem {
+	if p.Extensions == nil {
+		p.Extensions = make(map[string]interface{})
+	}
+	p.Extensions[key] = value
+	return p
+}
+
+// MarshalJSON encodes the problem with its extension members inlined
+func (p Problem) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+
+	first := true
+	write := func(key string, value interface{}) error {
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		if !first {
+			buf.WriteByte(',')
+		}
+		first = false
+		name, _ := json.Marshal(key)
+		buf.Write(name)
+		buf.WriteByte(':')
+		buf.Write(encoded)
+		return nil
+	}
+
+	for _, m := range p.members() {
+		if err := write(m.key, m.value); err != nil {
+			return nil, err
+		}
+	}
+
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// MarshalXML encodes the problem in the urn:ietf:rfc:7807 namespace
+func (p Problem) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	start = xml.StartElement{
+		Name: xml.Name{Local: "problem"},
+		Attr: []xml.Attr{{Name: xml.Name{Local: "xmlns"}, Value: problemNamespace}},
+	}
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+
+	for _, m := range p.members() {
+		if err := e.EncodeElement(xmlMap(m.value), xml.StartElement{Name: xml.Name{Local: m.key}}); err != nil {
+			return err
+		}
+	}
+
+	return e.EncodeToken(start.End())
+}
+
+type problemMember struct {
+	key   string
+	value interface{}
+}
+
+// members lists the non-empty standard members followed by the extension
+// members in key order
+func (p Problem) members() []problemMember {
+	var members []problemMember
+	if p.Type != "" {
+		members = append(members, problemMember{"type", p.Type})
+	}
+	if p.Title != "" {
+		members = append(members, problemMember{"title", p.Title})
+	}
+	if p.Status != 0 {
+		members = append(members, problemMember{"status", p.Status})
+	}
+	if p.Detail != "" {
+		members = append(members, problemMember{"detail", p.Detail})
+	}
+	if p.Instance != "" {
+		members = append(members, problemMember{"instance", p.Instance})
+	}
+
+	keys := make([]string, 0, len(p.Extensions))
+	for key := range p.Extensions {
+		switch key {
+		case "type", "title", "status", "detail", "instance":
+			continue
+		}
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		members = append(members, problemMember{key, p.Extensions[key]})
+	}
+	return members
+}
+
+// mediaType returns the problem media type matching the encoder's
+func (p Problem) mediaType(contentType string) string {
+	switch baseMediaType(contentType) {
+	case "application/json":
+		return "application/problem+json"
+	case "application/xml", "text/xml":
+		return "application/problem+xml"
+	}
+	return contentType
+}
+
+// mediaTyper is implemented by bodies that are sent with their own media
+// type, derived from the content type of the chosen encoder
+type mediaTyper interface {
+	mediaType(contentType string) string
+}
+
+// problemer is implemented by bodies that are sent as a problem document by
+// responses with DefaultProblem
+type problemer interface {
+	problem(code int) Problem
+}
+
+// ErrNilProblem is returned when Problem is given a nil problem, which is
+// answered with a 500 problem document
+var ErrNilProblem = errors.New("respond: nil problem")
+
+// Problem responds with a problem document. The status of the response is
+// taken from the problem, defaulting to 500, and the title defaults to the
+// status text when no type is given.
+func (resp *Response) Problem(p *Problem) error {
+	if p == nil {
+		if err := resp.Problem(&Problem{}); err != nil {
+			return err
+		}
+		return ErrNilProblem
+	}
+
+	problem := *p
+	if problem.Status == 0 {
+		problem.Status = http.StatusInternalServerError
+	}
+	if problem.Type == "" && problem.Title == "" {
+		problem.Title = http.StatusText(problem.Status)
+	}
+	return resp.writeResponse(problem.Status, problem)
+}
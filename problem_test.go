@@ -0,0 +1,169 @@
+package respond
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestProblem(t *testing.T) {
+	t.Parallel()
+
+	req := newRequest(t, "GET")
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		NewResponse(w).
+			Problem(&Problem{
+				Type:     "https://example.com/probs/out-of-credit",
+				Title:    "You do not have enough credit.",
+				Status:   http.StatusForbidden,
+				Detail:   "Your current balance is 30, but that costs 50.",
+				Instance: "/account/12345/msgs/abc",
+				Extensions: map[string]interface{}{
+					"balance": 30,
+					"status":  "ignored",
+				},
+			})
+	})
+	handler.ServeHTTP(rr, req)
+
+	if err := validateStatusCode(rr.Code, http.StatusForbidden); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := validateResponseHeader(rr.Header().Get("Content-Type"), "application/problem+json"); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `{"type":"https://example.com/probs/out-of-credit","title":"You do not have enough credit.",` +
+		`"status":403,"detail":"Your current balance is 30, but that costs 50.",` +
+		`"instance":"/account/12345/msgs/abc","balance":30}`
+	if err := validateResponseBody(rr.Body.String(), expected); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestProblemAsErrorBody(t *testing.T) {
+	t.Parallel()
+
+	req := newRequest(t, "GET")
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		NewResponse(w).
+			NotFound(NewProblem(http.StatusNotFound, "No such user").With("id", 7))
+	})
+	handler.ServeHTTP(rr, req)
+
+	if err := validateResponseHeader(rr.Header().Get("Content-Type"), "application/problem+json"); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `{"title":"Not Found","status":404,"detail":"No such user","id":7}`
+	if err := validateResponseBody(rr.Body.String(), expected); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDefaultProblem(t *testing.T) {
+	t.Parallel()
+
+	req := newRequest(t, "GET")
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		NewResponse(w).DefaultProblem().
+			Unauthorized(nil)
+	})
+	handler.ServeHTTP(rr, req)
+
+	if err := validateResponseHeader(rr.Header().Get("Content-Type"), "application/problem+json"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := validateResponseBody(rr.Body.String(), `{"title":"Unauthorized","status":401}`); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestProblemXML(t *testing.T) {
+	t.Parallel()
+
+	req := newRequest(t, "GET")
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		NewResponse(w).
+			WithEncoder(XMLEncoder{}).
+			Problem(NewProblem(http.StatusConflict, "Name taken"))
+	})
+	handler.ServeHTTP(rr, req)
+
+	if err := validateResponseHeader(rr.Header().Get("Content-Type"), "application/problem+xml"); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `<?xml version="1.0" encoding="UTF-8"?>` + "\n" +
+		`<problem xmlns="urn:ietf:rfc:7807"><title>Conflict</title><status>409</status>` +
+		`<detail>Name taken</detail></problem>`
+	if err := validateResponseBody(rr.Body.String(), expected); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestProblemNil(t *testing.T) {
+	t.Parallel()
+
+	req := newRequest(t, "GET")
+
+	var err error
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		err = NewResponse(w).Problem(nil)
+	})
+	handler.ServeHTTP(rr, req)
+
+	if err != ErrNilProblem {
+		t.Fatalf("expected ErrNilProblem, got %v", err)
+	}
+
+	if err := validateStatusCode(rr.Code, http.StatusInternalServerError); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := validateResponseHeader(rr.Header().Get("Content-Type"), "application/problem+json"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := validateResponseBody(rr.Body.String(), `{"title":"Internal Server Error","status":500}`); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestProblemXMLMapExtension(t *testing.T) {
+	t.Parallel()
+
+	req := newRequest(t, "GET")
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		NewResponse(w).
+			WithEncoder(XMLEncoder{}).
+			Problem(NewProblem(http.StatusForbidden, "Out of credit").
+				With("balance", map[string]interface{}{"currency": "EUR", "amount": 30, "limits": map[string]int{"daily": 50}}))
+	})
+	handler.ServeHTTP(rr, req)
+
+	if err := validateStatusCode(rr.Code, http.StatusForbidden); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `<?xml version="1.0" encoding="UTF-8"?>` + "\n" +
+		`<problem xmlns="urn:ietf:rfc:7807"><title>Forbidden</title><status>403</status>` +
+		`<detail>Out of credit</detail><balance><amount>30</amount><currency>EUR</currency>` +
+		`<limits><daily>50</daily></limits></balance></problem>`
+	if err := validateResponseBody(rr.Body.String(), expected); err != nil {
+		t.Fatal(err)
+	}
+}
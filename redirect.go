@@ -0,0 +1,62 @@
+package respond
+
+import (
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+)
+
+// redirect responds with a redirect to target. The target is resolved
+// against the request like http.Redirect does and sent in the Location
+// header, unless the response is turned into an error, e.g. a 406. A default
+// message for a redirect includes the location.
+func (resp *Response) redirect(code int, target string, v interface{}) error {
+	location := resp.resolveLocation(target)
+	resp.location = location
+
+	if v == nil && resp.DefMessage {
+		v = DefaultMessageResponse{
+			Status:   code,
+			Message:  http.StatusText(code),
+			Location: location,
+		}
+	}
+
+	return resp.writeResponse(code, v)
+}
+
+// resolveLocation makes a relative target absolute to the request path,
+// cleaning it while keeping any trailing slash and query
+func (resp *Response) resolveLocation(target string) string {
+	if resp.Request == nil || resp.Request.URL == nil {
+		return target
+	}
+
+	u, err := url.Parse(target)
+	if err != nil || u.Scheme != "" || u.Host != "" {
+		return target
+	}
+
+	if target == "" || target[0] != '/' {
+		oldPath := resp.Request.URL.Path
+		if oldPath == "" {
+			oldPath = "/"
+		}
+		oldDir, _ := path.Split(oldPath)
+		target = oldDir + target
+	}
+
+	var query string
+	if i := strings.Index(target, "?"); i != -1 {
+		target, query = target[:i], target[i:]
+	}
+
+	trailing := strings.HasSuffix(target, "/")
+	target = path.Clean(target)
+	if trailing && !strings.HasSuffix(target, "/") {
+		target += "/"
+	}
+
+	return target + query
+}
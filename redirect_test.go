@@ -0,0 +1,125 @@
+package respond
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+var redirectData = []struct {
+	testName string
+
+	requestPath string
+	target      string
+
+	expectedLocation string
+}{
+	{"absolute path", "/users/1", "/accounts/1", "/accounts/1"},
+	{"relative path", "/users/1", "2", "/users/2"},
+	{"parent path", "/users/1/posts", "../2", "/users/2"},
+	{"trailing slash kept", "/users/1", "./", "/users/"},
+	{"query kept", "/users/1", "/search?q=a/../b", "/search?q=a/../b"},
+	{"absolute url", "/users/1", "https://example.com/a/../b", "https://example.com/a/../b"},
+}
+
+func TestRedirectLocation(t *testing.T) {
+	for _, datum := range redirectData {
+		datum := datum
+		t.Run(datum.testName, func(t *testing.T) {
+			t.Parallel()
+
+			req, err := http.NewRequest("GET", datum.requestPath, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			rr := httptest.NewRecorder()
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				NewResponse(w).
+					WithRequest(r).
+					SeeOther(datum.target, nil)
+			})
+			handler.ServeHTTP(rr, req)
+
+			if err := validateStatusCode(rr.Code, http.StatusSeeOther); err != nil {
+				t.Fatal(err)
+			}
+
+			if err := validateResponseHeader(rr.Header().Get("Location"), datum.expectedLocation); err != nil {
+				t.Fatal(err)
+			}
+
+			if err := validateResponseBody(rr.Body.String(), ""); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestRedirectWithBody(t *testing.T) {
+	t.Parallel()
+
+	req := newRequest(t, "POST")
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		NewResponse(w).
+			Found("/users/1", &User{1, "Billy", "billy@example.com"})
+	})
+	handler.ServeHTTP(rr, req)
+
+	if err := validateResponseHeader(rr.Header().Get("Location"), "/users/1"); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `{"id":1,"name":"Billy","email":"billy@example.com"}`
+	if err := validateResponseBody(rr.Body.String(), expected); err != nil {
+		t.Fatal(err)
+	}
+}
+
+var redirectErrorData = []struct {
+	testName string
+
+	url    string
+	accept string
+
+	expectedStatus int
+}{
+	{"not acceptable", "/a/b", "text/html", http.StatusNotAcceptable},
+	{"invalid callback", "/a/b?callback=1bad", "", http.StatusBadRequest},
+}
+
+func TestRedirectReplacedByError(t *testing.T) {
+	for _, datum := range redirectErrorData {
+		datum := datum
+		t.Run(datum.testName, func(t *testing.T) {
+			t.Parallel()
+
+			req, err := http.NewRequest("GET", datum.url, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if datum.accept != "" {
+				req.Header.Set("Accept", datum.accept)
+			}
+
+			rr := httptest.NewRecorder()
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				NewResponse(w).
+					WithRequest(r).
+					JSONP("").
+					Found("c", nil)
+			})
+			handler.ServeHTTP(rr, req)
+
+			if err := validateStatusCode(rr.Code, datum.expectedStatus); err != nil {
+				t.Fatal(err)
+			}
+
+			if err := validateResponseHeader(rr.Header().Get("Location"), ""); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+// Code generated by gen_status.go. DO NOT EDIT.
+
+package respond
+
+import "net/http"
+
+// MultipleChoices returns a 300 Multiple Choices JSON response
+func (resp *Response) MultipleChoices(v interface{}) error {
+	return resp.writeResponse(http.StatusMultipleChoices, v)
+}
+
+// MovedPermanently redirects to url with a 301 Moved Permanently response and an
+// optional JSON body
+func (resp *Response) MovedPermanently(url string, v interface{}) error {
+	return resp.redirect(http.StatusMovedPermanently, url, v)
+}
+
+// Found redirects to url with a 302 Found response and an
+// optional JSON body
+func (resp *Response) Found(url string, v interface{}) error {
+	return resp.redirect(http.StatusFound, url, v)
+}
+
+// SeeOther redirects to url with a 303 See Other response and an
+// optional JSON body
+func (resp *Response) SeeOther(url string, v interface{}) error {
+	return resp.redirect(http.StatusSeeOther, url, v)
+}
+
+// NotModified returns a 304 Not Modified response
+func (resp *Response) NotModified() error {
+	return resp.writeResponse(http.StatusNotModified, nil)
+}
+
+// TemporaryRedirect redirects to url with a 307 Temporary Redirect response and an
+// optional JSON body
+func (resp *Response) TemporaryRedirect(url string, v interface{}) error {
+	return resp.redirect(http.StatusTemporaryRedirect, url, v)
+}
+
+// PermanentRedirect redirects to url with a 308 Permanent Redirect response and an
+// optional JSON body
+func (resp *Response) PermanentRedirect(url string, v interface{}) error {
+	return resp.redirect(http.StatusPermanentRedirect, url, v)
+}
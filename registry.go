@@ -0,0 +1,131 @@
+package respond
+
+import (
+	"errors"
+	"net/http"
+	"reflect"
+	"sync"
+)
+
+// ErrorBody builds the response body for an error matched by a registry
+type ErrorBody func(err error) interface{}
+
+// ErrorRegistry maps Go errors to response statuses and bodies
+type ErrorRegistry struct {
+	mu      sync.RWMutex
+	entries []errorEntry
+}
+
+type errorEntry struct {
+	match func(err error) (error, bool)
+	code  int
+	body  ErrorBody
+}
+
+// DefaultErrorRegistry is the registry used by responses that have not been
+// given one, so that a whole service can share a single error table
+var DefaultErrorRegistry = NewErrorRegistry()
+
+var errorType = reflect.TypeOf((*error)(nil)).Elem()
+
+// NewErrorRegistry creates and returns an empty error registry
+func NewErrorRegistry() *ErrorRegistry {
+	return &ErrorRegistry{}
+}
+
+// Register maps errors matching target, as reported by errors.Is, to the
+// status code. The body function is given the error being responded to and
+// may be nil, in which case the response has no body.
+func (reg *ErrorRegistry) Register(target error, code int, body ErrorBody) *ErrorRegistry {
+	return reg.add(errorEntry{
+		match: func(err error) (error, bool) {
+			return err, errors.Is(err, target)
+		},
+		code: code,
+		body: body,
+	})
+}
+
+// RegisterType maps errors of a type, as reported by errors.As, to the status
+// code. target is a pointer to the error type in the form passed to
+// errors.As, e.g. new(*NotFoundError). The body function is given the
+// matching error from the chain and may be nil.
+func (reg *ErrorRegistry) RegisterType(target interface{}, code int, body ErrorBody) *ErrorRegistry {
+	typ := reflect.TypeOf(target)
+	if typ == nil || typ.Kind() != reflect.Ptr {
+		panic("respond: RegisterType target must be a non-nil pointer")
+	}
+
+	elem := typ.Elem()
+	if elem.Kind() != reflect.Interface && !elem.Implements(errorType) {
+		panic("respond: RegisterType target must point to an interface or a type implementing error")
+	}
+
+	return reg.add(errorEntry{
+		match: func(err error) (error, bool) {
+			ptr := reflect.New(elem)
+			if !errors.As(err, ptr.Interface()) {
+				return nil, false
+			}
+			matched, ok := ptr.Elem().Interface().(error)
+			if !ok {
+				matched = err
+			}
+			return matched, true
+		},
+		code: code,
+		body: body,
+	})
+}
+
+func (reg *ErrorRegistry) add(entry errorEntry) *ErrorRegistry {
+	reg.mu.Lock()
+	defer reg.mu.Unlock()
+
+	reg.entries = append(reg.entries, entry)
+	return reg
+}
+
+// Lookup returns the status code and body for err from the first matching
+// registration. Unmatched errors map to a 500 with no body.
+func (reg *ErrorRegistry) Lookup(err error) (int, interface{}) {
+	reg.mu.RLock()
+	defer reg.mu.RUnlock()
+
+	for _, entry := range reg.entries {
+		matched, ok := entry.match(err)
+		if !ok {
+			continue
+		}
+		if entry.body == nil {
+			return entry.code, nil
+		}
+		return entry.code, entry.body(matched)
+	}
+
+	return http.StatusInternalServerError, nil
+}
+
+// WithErrorRegistry sets the registry used by Error
+func (resp *Response) WithErrorRegistry(reg *ErrorRegistry) *Response {
+	resp.ErrorRegistry = reg
+	return resp
+}
+
+// Error responds to err with the status and body it is mapped to in the
+// response's error registry, or in DefaultErrorRegistry when none is set.
+// Unmapped errors are answered with a 500 Internal Server Error. Nothing is
+// written for a nil error.
+func (resp *Response) Error(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	reg := resp.ErrorRegistry
+	if reg == nil {
+		reg = DefaultErrorRegistry
+	}
+
+	code, body := reg.Lookup(err)
+	return resp.writeResponse(code, body)
+}
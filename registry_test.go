@@ -0,0 +1,109 @@
+package respond
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+var errUserNotFound = errors.New("user not found")
+
+type conflictError struct {
+	Field string
+}
+
+func (e *conflictError) Error() string {
+	return e.Field + " already taken"
+}
+
+func testErrorRegistry() *ErrorRegistry {
+	return NewErrorRegistry().
+		Register(errUserNotFound, http.StatusNotFound, nil).
+		RegisterType(new(*conflictError), http.StatusConflict, func(err error) interface{} {
+			return &Error{409, err.Error()}
+		})
+}
+
+var registryData = []struct {
+	testName string
+
+	inputError error
+
+	expectedStatus int
+	expectedBody   string
+}{
+	{"sentinel error",
+		errUserNotFound,
+		http.StatusNotFound, `{"status":404,"message":"Not Found"}`},
+	{"wrapped sentinel error",
+		fmt.Errorf("loading profile: %w", errUserNotFound),
+		http.StatusNotFound, `{"status":404,"message":"Not Found"}`},
+	{"error type",
+		fmt.Errorf("saving user: %w", &conflictError{"username"}),
+		http.StatusConflict, `{"code":409,"message":"username already taken"}`},
+	{"unmapped error",
+		errors.New("boom"),
+		http.StatusInternalServerError, `{"status":500,"message":"Internal Server Error"}`},
+}
+
+func TestError(t *testing.T) {
+	for _, datum := range registryData {
+		datum := datum
+		t.Run(datum.testName, func(t *testing.T) {
+			t.Parallel()
+
+			req := newRequest(t, "GET")
+
+			rr := httptest.NewRecorder()
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				NewResponse(w).
+					WithErrorRegistry(testErrorRegistry()).
+					DefaultMessage().
+					Error(datum.inputError)
+			})
+			handler.ServeHTTP(rr, req)
+
+			if err := validateStatusCode(rr.Code, datum.expectedStatus); err != nil {
+				t.Fatal(err)
+			}
+
+			if err := validateResponseBody(rr.Body.String(), datum.expectedBody); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestDefaultErrorRegistry(t *testing.T) {
+	t.Parallel()
+
+	errGone := errors.New("resource removed")
+	DefaultErrorRegistry.Register(errGone, http.StatusGone, nil)
+
+	req := newRequest(t, "GET")
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		NewResponse(w).
+			Error(errGone)
+	})
+	handler.ServeHTTP(rr, req)
+
+	if err := validateStatusCode(rr.Code, http.StatusGone); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRegisterTypeInvalidTarget(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("registering a non-pointer target should have caused a panic")
+		}
+	}()
+
+	t.Parallel()
+
+	NewErrorRegistry().RegisterType(conflictError{}, http.StatusConflict, nil)
+}
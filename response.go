@@ -1,38 +1,151 @@
 package respond
 
+//go:generate go run gen_status.go
+
 import (
-	"encoding/json"
+	"encoding/xml"
+	"errors"
 	"net/http"
+	"strings"
+	"time"
 )
 
+// ErrNotAcceptable is returned when none of the registered encoders can
+// produce a content type accepted by the request
+var ErrNotAcceptable = errors.New("respond: no acceptable content type")
+
 // Response is the HTTP response
 type Response struct {
 	Writer     http.ResponseWriter
 	Headers    map[string]string
 	DefMessage bool
+	DefProblem bool
+
+	// Request is the request being answered. When set, the Accept header is
+	// used to choose one of the Encoders.
+	Request *http.Request
+
+	// Encoders are the encoders available to the response, in order of
+	// preference. The first one is used when the request does not state a
+	// preference.
+	Encoders []Encoder
+
+	// Encoder, when set, is always used to encode the body and content
+	// negotiation is skipped.
+	Encoder Encoder
+
+	// Compression enables compressing bodies of at least CompressMinSize
+	// bytes with a content coding accepted by the request
+	Compression     bool
+	CompressMinSize int
+
+	// CachePolicy is sent in the Cache-Control header of successful and
+	// redirect responses. When nil the DefaultCachePolicy is used.
+	CachePolicy *CachePolicy
+
+	// Formatter shapes bodies before they are encoded. When nil the
+	// DefaultFormatter is used.
+	Formatter Formatter
+
+	// Metadata holds the members added to the meta block of formatted
+	// responses
+	Metadata Meta
+
+	// Page describes the page of a collection held by successful responses
+	Page *Page
+
+	// ETagMode selects how ETags are generated for successful responses
+	ETagMode ETagMode
+
+	// Modified is the modification time of the resource, used for the
+	// Last-Modified header and conditional requests
+	Modified time.Time
+
+	// FlushEvery is the number of values written to a stream between
+	// flushes. When zero DefaultFlushEvery is used.
+	FlushEvery int
+
+	// ErrorRegistry maps errors passed to Error to responses. When nil the
+	// DefaultErrorRegistry is used.
+	ErrorRegistry *ErrorRegistry
+
+	// PrettyPrint sets whether bodies are pretty-printed. When nil
+	// DefaultPretty is used.
+	PrettyPrint *bool
+
+	// SparseFields prunes successful JSON bodies down to the fields listed
+	// in the request's fields query parameter
+	SparseFields bool
+
+	// CallbackParam is the query parameter naming the callback JSON bodies
+	// are wrapped in for JSONP. When empty JSONP is disabled.
+	CallbackParam string
+
+	// FallbackBody is sent with a 500 status when the body of a response
+	// cannot be marshalled. When nil a DefaultMessageResponse is sent.
+	FallbackBody interface{}
+
+	// location is the target of a redirect, sent in the Location header
+	// when the response is still a redirect once written
+	location string
 }
 
 // DefaultMessageResponse is for transporting a default http message
 type DefaultMessageResponse struct {
-	Status  int    `json:"status"`
-	Message string `json:"message"`
+	XMLName  xml.Name `json:"-" xml:"response"`
+	Status   int      `json:"status" xml:"status"`
+	Message  string   `json:"message" xml:"message"`
+	Location string   `json:"location,omitempty" xml:"location,omitempty"`
 }
 
 // NewResponse creates and returns a new response
 func NewResponse(w http.ResponseWriter) *Response {
 	return &Response{
-		Writer: w,
-		Headers: map[string]string{
-			"Content-Type": "application/json; charset=utf-8",
-		},
+		Writer:   w,
+		Headers:  map[string]string{},
+		Encoders: []Encoder{JSONEncoder{}},
 	}
 }
 
+// WithRequest sets the request being answered, enabling content negotiation
+func (resp *Response) WithRequest(r *http.Request) *Response {
+	resp.Request = r
+	return resp
+}
+
+// WithEncoder sets the encoder used for the body, bypassing negotiation
+func (resp *Response) WithEncoder(enc Encoder) *Response {
+	resp.Encoder = enc
+	return resp
+}
+
+// AddEncoder registers an additional encoder for content negotiation
+func (resp *Response) AddEncoder(enc Encoder) *Response {
+	resp.Encoders = append(resp.Encoders, enc)
+	return resp
+}
+
+// DefaultMessage responds with a DefaultMessageResponse when no body is given
 func (resp *Response) DefaultMessage() *Response {
 	resp.DefMessage = true
 	return resp
 }
 
+// DefaultProblem responds with a Problem document instead of a
+// DefaultMessageResponse when no body is given for an error status. It
+// implies DefaultMessage.
+func (resp *Response) DefaultProblem() *Response {
+	resp.DefMessage = true
+	resp.DefProblem = true
+	return resp
+}
+
+// Fallback sets the body sent with a 500 status when marshalling fails
+func (resp *Response) Fallback(v interface{}) *Response {
+	resp.FallbackBody = v
+	return resp
+}
+
 // DeleteHeader deletes a single header from the response
 func (resp *Response) DeleteHeader(key string) *Response {
 	resp.Writer.Header().Del(key)
@@ -45,41 +158,196 @@ func (resp *Response) AddHeader(key string, value string) *Response {
 	return resp
 }
 
-// WriteResponse writes the HTTP response status, headers and body
+// writeResponse writes the HTTP response status, headers and body. The body
+// is marshalled before anything is written, so that a marshalling failure can
+// be answered with a 500 instead of the requested status. Any error from
+// marshalling v or from writing to the underlying connection is returned to
+// the caller.
 func (resp *Response) writeResponse(code int, v interface{}) error {
-	if len(resp.Headers) > 0 {
-		resp.writeHeaders()
+	var respErr error
+	var callback string
+
+	enc, ok := resp.negotiate()
+	if !ok {
+		code, v = http.StatusNotAcceptable, nil
+		respErr = ErrNotAcceptable
+	} else if callback, respErr = resp.jsonpCallback(enc); respErr != nil {
+		code, v = http.StatusBadRequest, nil
 	}
 
-	resp.writeStatusCode(code)
+	if !bodyAllowed(code) {
+		v = nil
+	} else if v == nil && resp.DefMessage {
+		v = resp.defaultBody(code)
+	} else if p, ok := v.(problemer); ok && resp.DefProblem && code >= http.StatusBadRequest {
+		v = p.problem(code)
+	}
 
-	if v == nil && resp.DefMessage {
-		v = DefaultMessageResponse{
-			Status:  code,
-			Message: http.StatusText(code),
-		}
+	v = resp.selectFields(enc, code, v)
+	v = resp.format(enc, code, v)
+
+	body, err := resp.encode(enc, v)
+	if err != nil {
+		code = http.StatusInternalServerError
+		v, body = resp.fallback(enc)
+		respErr = err
 	}
 
-	if v != nil {
-		body, err := json.Marshal(v)
-		if err != nil {
-			panic(err)
-		}
+	contentType := enc.ContentType()
+	if mt, ok := v.(mediaTyper); ok {
+		contentType = mt.mediaType(contentType)
+	}
+
+	if callback != "" && body != nil {
+		body = wrapJSONP(callback, body)
+		contentType = jsonpContentType
+		resp.Writer.Header().Set("X-Content-Type-Options", "nosniff")
+	}
+
+	resp.writeHeaders(code, contentType)
+
+	coding := resp.contentCoding(body)
+	code, body = resp.checkConditions(code, body, coding >= 0)
+	body = resp.compress(body, coding)
+
+	resp.writeStatusCode(code)
+
+	if body != nil {
 		// can just return an error when connection is hijacked or content-size is longer then declared.
 		if _, err := resp.Writer.Write(body); err != nil {
-			panic(err)
+			return err
+		}
+	}
+
+	return respErr
+}
+
+// negotiate chooses the encoder for the response. When nothing is acceptable
+// to the request the first encoder is returned along with false.
+func (resp *Response) negotiate() (Encoder, bool) {
+	if resp.Encoder != nil {
+		return resp.Encoder, true
+	}
+
+	encoders := resp.Encoders
+	if len(encoders) == 0 {
+		encoders = []Encoder{JSONEncoder{}}
+	}
+
+	if resp.Request == nil {
+		return encoders[0], true
+	}
+
+	accept := strings.Join(resp.Request.Header["Accept"], ",")
+	if enc, ok := negotiate(accept, encoders); ok {
+		return enc, true
+	}
+	return encoders[0], false
+}
+
+func (resp *Response) encode(enc Encoder, v interface{}) ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	if ie, ok := enc.(IndentEncoder); ok && resp.pretty(enc) {
+		return ie.EncodeIndent(v, prettyIndent)
+	}
+	return enc.Encode(v)
+}
+
+// defaultBody returns the body sent for the status when none is given
+func (resp *Response) defaultBody(code int) interface{} {
+	if resp.DefProblem && code >= http.StatusBadRequest {
+		return Problem{
+			Title:  http.StatusText(code),
+			Status: code,
 		}
 	}
 
-	return nil
+	return DefaultMessageResponse{
+		Status:  code,
+		Message: http.StatusText(code),
+	}
 }
 
-func (resp *Response) writeHeaders() {
+// fallback returns the body sent in place of one that could not be encoded,
+// along with its encoding. The body is sent unformatted when the formatted
+// body cannot be encoded either.
+func (resp *Response) fallback(enc Encoder) (interface{}, []byte) {
+	v := resp.FallbackBody
+	if v == nil {
+		v = resp.defaultBody(http.StatusInternalServerError)
+	}
+
+	formatted := resp.format(enc, http.StatusInternalServerError, v)
+	if body, err := resp.encode(enc, formatted); err == nil {
+		return formatted, body
+	}
+
+	body, err := resp.encode(enc, v)
+	if err != nil {
+		return nil, nil
+	}
+	return v, body
+}
+
+func (resp *Response) writeHeaders(code int, contentType string) {
+	header := resp.Writer.Header()
+
+	if _, ok := resp.Headers["Content-Type"]; !ok {
+		header.Set("Content-Type", contentType)
+	}
+
+	policy := resp.CachePolicy
+	if policy == nil {
+		policy = DefaultCachePolicy
+	}
+	if policy != nil && code < http.StatusBadRequest && header.Get("Cache-Control") == "" {
+		if value := policy.String(); value != "" {
+			header.Set("Cache-Control", value)
+		}
+	}
+
+	if resp.Page != nil && code >= 200 && code <= 299 {
+		resp.writePageHeaders(header)
+	}
+
+	if resp.location != "" && code >= 300 && code <= 399 {
+		header.Set("Location", resp.location)
+	}
+
 	for key, value := range resp.Headers {
-		resp.Writer.Header().Set(key, value)
+		header.Set(key, value)
+	}
+
+	if resp.Request != nil && resp.Encoder == nil && len(resp.Encoders) > 1 {
+		addVary(header, "Accept")
 	}
 }
 
 func (resp *Response) writeStatusCode(code int) {
 	resp.Writer.WriteHeader(code)
 }
+
+// bodyAllowed reports whether a response with the status may have a body
+func bodyAllowed(code int) bool {
+	switch {
+	case code >= 100 && code <= 199:
+		return false
+	case code == http.StatusNoContent, code == http.StatusResetContent, code == http.StatusNotModified:
+		return false
+	}
+	return true
+}
+
+// addVary adds a field name to the Vary header unless it is already listed
+func addVary(header http.Header, field string) {
+	for _, value := range header["Vary"] {
+		for _, existing := range strings.Split(value, ",") {
+			if strings.EqualFold(strings.TrimSpace(existing), field) {
+				return
+			}
+		}
+	}
+	header.Add("Vary", field)
+}
@@ -58,22 +58,79 @@ func TestDefaultMessage(t *testing.T) {
 }
 
 func TestRespondInvalidType(t *testing.T) {
-	defer func() {
-		if r := recover(); r == nil {
-			t.Errorf("responding with invalid type (channel) should have caused a panic")
-		}
-	}()
-
 	t.Parallel()
 
 	req := newRequest(t, "GET")
 
+	var respErr error
 	rr := httptest.NewRecorder()
 	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		NewResponse(w).DefaultMessage().
+		respErr = NewResponse(w).DefaultMessage().
 			Unauthorized(make(chan int))
 	})
 	handler.ServeHTTP(rr, req)
+
+	if respErr == nil {
+		t.Fatal("responding with invalid type (channel) should have returned an error")
+	}
+
+	if err := validateStatusCode(rr.Code, http.StatusInternalServerError); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `{"status":500,"message":"Internal Server Error"}`
+	if err := validateResponseBody(rr.Body.String(), expected); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestFallback(t *testing.T) {
+	t.Parallel()
+
+	req := newRequest(t, "GET")
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		NewResponse(w).
+			Fallback(&Error{500, "Something went wrong"}).
+			Ok(map[string]interface{}{"ch": make(chan int)})
+	})
+	handler.ServeHTTP(rr, req)
+
+	if err := validateStatusCode(rr.Code, http.StatusInternalServerError); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `{"code":500,"message":"Something went wrong"}`
+	if err := validateResponseBody(rr.Body.String(), expected); err != nil {
+		t.Fatal(err)
+	}
+}
+
+type failingWriter struct {
+	*httptest.ResponseRecorder
+}
+
+func (w failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("connection closed")
+}
+
+func TestRespondWriteError(t *testing.T) {
+	t.Parallel()
+
+	req := newRequest(t, "GET")
+
+	var respErr error
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		respErr = NewResponse(failingWriter{rr}).
+			Ok(&User{1, "Billy", "billy@example.com"})
+	})
+	handler.ServeHTTP(rr, req)
+
+	if respErr == nil || respErr.Error() != "connection closed" {
+		t.Fatalf("expected write error to be returned, got %v", respErr)
+	}
 }
 
 func TestContentTypeHeader(t *testing.T) {
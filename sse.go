@@ -0,0 +1,180 @@
+package respond
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrFlushUnsupported is returned when an event stream is opened on a
+// response writer that cannot be flushed
+var ErrFlushUnsupported = errors.New("respond: response writer does not support flushing")
+
+// ErrInvalidEvent is returned when the id or type of an event contains a
+// line break, which cannot be sent in an event stream
+var ErrInvalidEvent = errors.New("respond: event id and type must not contain line breaks")
+
+// Event is a single server-sent event
+type Event struct {
+	// ID sets the event stream's last event ID, used to resume the stream
+	ID string
+	// Event is the event type, dispatched as "message" when empty
+	Event string
+	// Data is the payload of the event, encoded like a response body
+	Data interface{}
+	// Retry is the reconnection time the client should use
+	Retry time.Duration
+}
+
+// EventStream writes server-sent events (text/event-stream) to a response.
+// It is safe for concurrent use.
+type EventStream struct {
+	resp    *Response
+	enc     Encoder
+	flusher http.Flusher
+
+	mu sync.Mutex
+}
+
+// EventStream starts a 200 OK event stream on the response. Event data is
+// encoded with the response's Encoder, or its first registered encoder. An
+// error is returned, after responding with a 500, if the response writer
+// cannot be flushed.
+func (resp *Response) EventStream() (*EventStream, error) {
+	flusher, ok := resp.Writer.(http.Flusher)
+	if !ok {
+		resp.InternalServerError(nil)
+		return nil, ErrFlushUnsupported
+	}
+
+	enc := resp.Encoder
+	if enc == nil && len(resp.Encoders) > 0 {
+		enc = resp.Encoders[0]
+	}
+	if enc == nil {
+		enc = JSONEncoder{}
+	}
+
+	header := resp.Writer.Header()
+	header.Set("Cache-Control", "no-cache")
+	header.Set("X-Accel-Buffering", "no")
+
+	resp.writeHeaders(http.StatusOK, "text/event-stream; charset=utf-8")
+	resp.writeStatusCode(http.StatusOK)
+	flusher.Flush()
+
+	return &EventStream{
+		resp:    resp,
+		enc:     enc,
+		flusher: flusher,
+	}, nil
+}
+
+// LastEventID returns the ID of the last event received by a reconnecting
+// client, from the Last-Event-ID request header
+func (s *EventStream) LastEventID() string {
+	if s.resp.Request == nil {
+		return ""
+	}
+	return s.resp.Request.Header.Get("Last-Event-ID")
+}
+
+// Send writes an event to the stream and flushes it
+func (s *EventStream) Send(e Event) error {
+	if strings.ContainsAny(e.ID, "\r\n\x00") || strings.ContainsAny(e.Event, "\r\n") {
+		return ErrInvalidEvent
+	}
+
+	var buf bytes.Buffer
+	if e.ID != "" {
+		buf.WriteString("id: " + e.ID + "\n")
+	}
+	if e.Event != "" {
+		buf.WriteString("event: " + e.Event + "\n")
+	}
+	if e.Retry > 0 {
+		buf.WriteString("retry: " + strconv.FormatInt(int64(e.Retry/time.Millisecond), 10) + "\n")
+	}
+	if e.Data != nil {
+		data, err := s.enc.Encode(e.Data)
+		if err != nil {
+			return err
+		}
+		for _, line := range splitLines(string(data)) {
+			buf.WriteString("data: " + line + "\n")
+		}
+	}
+	buf.WriteByte('\n')
+
+	return s.write(buf.Bytes())
+}
+
+// Comment writes a comment to the stream and flushes it. Comments are
+// ignored by clients, and keep idle connections open.
+func (s *EventStream) Comment(text string) error {
+	var buf bytes.Buffer
+	for _, line := range splitLines(text) {
+		buf.WriteString(": " + line + "\n")
+	}
+	buf.WriteByte('\n')
+
+	return s.write(buf.Bytes())
+}
+
+// Heartbeat writes an empty comment to the stream at every interval, until
+// the returned stop function is called, a write fails or the request
+// context is done. stop must be called before the handler returns.
+func (s *EventStream) Heartbeat(interval time.Duration) (stop func()) {
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	ctx := s.resp.context()
+
+	go func() {
+		defer close(stopped)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				if err := s.Comment(""); err != nil {
+					return
+				}
+			case <-done:
+				return
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() { close(done) })
+		<-stopped
+	}
+}
+
+func (s *EventStream) write(p []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, err := s.resp.Writer.Write(p); err != nil {
+		return err
+	}
+	s.flusher.Flush()
+	return nil
+}
+
+// splitLines splits text on any of the line endings allowed in an event
+// stream
+func splitLines(text string) []string {
+	text = strings.Replace(text, "\r\n", "\n", -1)
+	text = strings.Replace(text, "\r", "\n", -1)
+	return strings.Split(text, "\n")
+}
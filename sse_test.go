@@ -0,0 +1,125 @@
+package respond
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEventStream(t *testing.T) {
+	t.Parallel()
+
+	req := newRequest(t, "GET")
+	req.Header.Set("Last-Event-ID", "41")
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		stream, err := NewResponse(w).WithRequest(r).EventStream()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err := validateResponseHeader(stream.LastEventID(), "41"); err != nil {
+			t.Error(err)
+		}
+
+		stream.Send(Event{
+			ID:    "42",
+			Event: "progress",
+			Data:  map[string]int{"percent": 50},
+			Retry: 3 * time.Second,
+		})
+		stream.Comment("multi\nline")
+		stream.Send(Event{Data: "a\nb"})
+	})
+	handler.ServeHTTP(rr, req)
+
+	if err := validateStatusCode(rr.Code, http.StatusOK); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := validateResponseHeader(rr.Header().Get("Content-Type"), "text/event-stream; charset=utf-8"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := validateResponseHeader(rr.Header().Get("Cache-Control"), "no-cache"); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "id: 42\nevent: progress\nretry: 3000\ndata: {\"percent\":50}\n\n" +
+		": multi\n: line\n\n" +
+		"data: \"a\\nb\"\n\n"
+	if err := validateResponseBody(rr.Body.String(), expected); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestEventStreamInvalidEvent(t *testing.T) {
+	t.Parallel()
+
+	req := newRequest(t, "GET")
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		stream, err := NewResponse(w).EventStream()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err := stream.Send(Event{Event: "a\nb"}); err != ErrInvalidEvent {
+			t.Errorf("expected ErrInvalidEvent, got %v", err)
+		}
+	})
+	handler.ServeHTTP(rr, req)
+
+	if err := validateResponseBody(rr.Body.String(), ""); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestEventStreamHeartbeat(t *testing.T) {
+	t.Parallel()
+
+	req := newRequest(t, "GET")
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		stream, err := NewResponse(w).EventStream()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		stop := stream.Heartbeat(time.Millisecond)
+		time.Sleep(20 * time.Millisecond)
+		stop()
+	})
+	handler.ServeHTTP(rr, req)
+
+	if !strings.HasPrefix(rr.Body.String(), ": \n\n") {
+		t.Fatalf("expected heartbeat comments, got %q", rr.Body.String())
+	}
+}
+
+type unflushableWriter struct {
+	http.ResponseWriter
+}
+
+func TestEventStreamUnflushable(t *testing.T) {
+	t.Parallel()
+
+	req := newRequest(t, "GET")
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, err := NewResponse(unflushableWriter{w}).EventStream(); err != ErrFlushUnsupported {
+			t.Errorf("expected ErrFlushUnsupported, got %v", err)
+		}
+	})
+	handler.ServeHTTP(rr, req)
+
+	if err := validateStatusCode(rr.Code, http.StatusInternalServerError); err != nil {
+		t.Fatal(err)
+	}
+}
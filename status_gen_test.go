@@ -0,0 +1,1269 @@
+// Code generated by gen_status.go. DO NOT EDIT.
+
+package respond
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestStatusOk(t *testing.T) {
+	t.Parallel()
+
+	req := newRequest(t, "GET")
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		NewResponse(w).
+			Ok(&Error{200, "OK"})
+	})
+	handler.ServeHTTP(rr, req)
+
+	if err := validateStatusCode(rr.Code, http.StatusOK); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	expected := `{"code":200,"message":"OK"}`
+	if err := validateResponseBody(rr.Body.String(), expected); err != nil {
+		t.Fatal(err.Error())
+	}
+}
+
+func TestStatusCreated(t *testing.T) {
+	t.Parallel()
+
+	req := newRequest(t, "GET")
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		NewResponse(w).
+			Created(&Error{201, "Created"})
+	})
+	handler.ServeHTTP(rr, req)
+
+	if err := validateStatusCode(rr.Code, http.StatusCreated); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	expected := `{"code":201,"message":"Created"}`
+	if err := validateResponseBody(rr.Body.String(), expected); err != nil {
+		t.Fatal(err.Error())
+	}
+}
+
+func TestStatusAccepted(t *testing.T) {
+	t.Parallel()
+
+	req := newRequest(t, "GET")
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		NewResponse(w).
+			Accepted(&Error{202, "Accepted"})
+	})
+	handler.ServeHTTP(rr, req)
+
+	if err := validateStatusCode(rr.Code, http.StatusAccepted); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	expected := `{"code":202,"message":"Accepted"}`
+	if err := validateResponseBody(rr.Body.String(), expected); err != nil {
+		t.Fatal(err.Error())
+	}
+}
+
+func TestStatusNonAuthoritativeInfo(t *testing.T) {
+	t.Parallel()
+
+	req := newRequest(t, "GET")
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		NewResponse(w).
+			NonAuthoritativeInfo(&Error{203, "Non-Authoritative Information"})
+	})
+	handler.ServeHTTP(rr, req)
+
+	if err := validateStatusCode(rr.Code, http.StatusNonAuthoritativeInfo); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	expected := `{"code":203,"message":"Non-Authoritative Information"}`
+	if err := validateResponseBody(rr.Body.String(), expected); err != nil {
+		t.Fatal(err.Error())
+	}
+}
+
+func TestStatusNoContent(t *testing.T) {
+	t.Parallel()
+
+	req := newRequest(t, "GET")
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		NewResponse(w).
+			DefaultMessage().
+			NoContent()
+	})
+	handler.ServeHTTP(rr, req)
+
+	if err := validateStatusCode(rr.Code, http.StatusNoContent); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	expected := ""
+	if err := validateResponseBody(rr.Body.String(), expected); err != nil {
+		t.Fatal(err.Error())
+	}
+}
+
+func TestStatusResetContent(t *testing.T) {
+	t.Parallel()
+
+	req := newRequest(t, "GET")
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		NewResponse(w).
+			DefaultMessage().
+			ResetContent()
+	})
+	handler.ServeHTTP(rr, req)
+
+	if err := validateStatusCode(rr.Code, http.StatusResetContent); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	expected := ""
+	if err := validateResponseBody(rr.Body.String(), expected); err != nil {
+		t.Fatal(err.Error())
+	}
+}
+
+func TestStatusPartialContent(t *testing.T) {
+	t.Parallel()
+
+	req := newRequest(t, "GET")
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		NewResponse(w).
+			PartialContent(&Error{206, "Partial Content"})
+	})
+	handler.ServeHTTP(rr, req)
+
+	if err := validateStatusCode(rr.Code, http.StatusPartialContent); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	expected := `{"code":206,"message":"Partial Content"}`
+	if err := validateResponseBody(rr.Body.String(), expected); err != nil {
+		t.Fatal(err.Error())
+	}
+}
+
+func TestStatusMultiStatus(t *testing.T) {
+	t.Parallel()
+
+	req := newRequest(t, "GET")
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		NewResponse(w).
+			MultiStatus(&Error{207, "Multi-Status"})
+	})
+	handler.ServeHTTP(rr, req)
+
+	if err := validateStatusCode(rr.Code, http.StatusMultiStatus); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	expected := `{"code":207,"message":"Multi-Status"}`
+	if err := validateResponseBody(rr.Body.String(), expected); err != nil {
+		t.Fatal(err.Error())
+	}
+}
+
+func TestStatusAlreadyReported(t *testing.T) {
+	t.Parallel()
+
+	req := newRequest(t, "GET")
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		NewResponse(w).
+			AlreadyReported(&Error{208, "Already Reported"})
+	})
+	handler.ServeHTTP(rr, req)
+
+	if err := validateStatusCode(rr.Code, http.StatusAlreadyReported); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	expected := `{"code":208,"message":"Already Reported"}`
+	if err := validateResponseBody(rr.Body.String(), expected); err != nil {
+		t.Fatal(err.Error())
+	}
+}
+
+func TestStatusIMUsed(t *testing.T) {
+	t.Parallel()
+
+	req := newRequest(t, "GET")
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		NewResponse(w).
+			IMUsed(&Error{226, "IM Used"})
+	})
+	handler.ServeHTTP(rr, req)
+
+	if err := validateStatusCode(rr.Code, http.StatusIMUsed); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	expected := `{"code":226,"message":"IM Used"}`
+	if err := validateResponseBody(rr.Body.String(), expected); err != nil {
+		t.Fatal(err.Error())
+	}
+}
+
+func TestStatusMultipleChoices(t *testing.T) {
+	t.Parallel()
+
+	req := newRequest(t, "GET")
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		NewResponse(w).
+			MultipleChoices(&Error{300, "Multiple Choices"})
+	})
+	handler.ServeHTTP(rr, req)
+
+	if err := validateStatusCode(rr.Code, http.StatusMultipleChoices); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	expected := `{"code":300,"message":"Multiple Choices"}`
+	if err := validateResponseBody(rr.Body.String(), expected); err != nil {
+		t.Fatal(err.Error())
+	}
+}
+
+func TestStatusMovedPermanently(t *testing.T) {
+	t.Parallel()
+
+	req := newRequest(t, "GET")
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		NewResponse(w).
+			DefaultMessage().
+			MovedPermanently("/users/1", nil)
+	})
+	handler.ServeHTTP(rr, req)
+
+	if err := validateStatusCode(rr.Code, http.StatusMovedPermanently); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	if err := validateResponseHeader(rr.Header().Get("Location"), "/users/1"); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	expected := `{"status":301,"message":"Moved Permanently","location":"/users/1"}`
+	if err := validateResponseBody(rr.Body.String(), expected); err != nil {
+		t.Fatal(err.Error())
+	}
+}
+
+func TestStatusFound(t *testing.T) {
+	t.Parallel()
+
+	req := newRequest(t, "GET")
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		NewResponse(w).
+			DefaultMessage().
+			Found("/users/1", nil)
+	})
+	handler.ServeHTTP(rr, req)
+
+	if err := validateStatusCode(rr.Code, http.StatusFound); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	if err := validateResponseHeader(rr.Header().Get("Location"), "/users/1"); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	expected := `{"status":302,"message":"Found","location":"/users/1"}`
+	if err := validateResponseBody(rr.Body.String(), expected); err != nil {
+		t.Fatal(err.Error())
+	}
+}
+
+func TestStatusSeeOther(t *testing.T) {
+	t.Parallel()
+
+	req := newRequest(t, "GET")
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		NewResponse(w).
+			DefaultMessage().
+			SeeOther("/users/1", nil)
+	})
+	handler.ServeHTTP(rr, req)
+
+	if err := validateStatusCode(rr.Code, http.StatusSeeOther); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	if err := validateResponseHeader(rr.Header().Get("Location"), "/users/1"); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	expected := `{"status":303,"message":"See Other","location":"/users/1"}`
+	if err := validateResponseBody(rr.Body.String(), expected); err != nil {
+		t.Fatal(err.Error())
+	}
+}
+
+func TestStatusNotModified(t *testing.T) {
+	t.Parallel()
+
+	req := newRequest(t, "GET")
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		NewResponse(w).
+			DefaultMessage().
+			NotModified()
+	})
+	handler.ServeHTTP(rr, req)
+
+	if err := validateStatusCode(rr.Code, http.StatusNotModified); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	expected := ""
+	if err := validateResponseBody(rr.Body.String(), expected); err != nil {
+		t.Fatal(err.Error())
+	}
+}
+
+func TestStatusTemporaryRedirect(t *testing.T) {
+	t.Parallel()
+
+	req := newRequest(t, "GET")
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		NewResponse(w).
+			DefaultMessage().
+			TemporaryRedirect("/users/1", nil)
+	})
+	handler.ServeHTTP(rr, req)
+
+	if err := validateStatusCode(rr.Code, http.StatusTemporaryRedirect); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	if err := validateResponseHeader(rr.Header().Get("Location"), "/users/1"); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	expected := `{"status":307,"message":"Temporary Redirect","location":"/users/1"}`
+	if err := validateResponseBody(rr.Body.String(), expected); err != nil {
+		t.Fatal(err.Error())
+	}
+}
+
+func TestStatusPermanentRedirect(t *testing.T) {
+	t.Parallel()
+
+	req := newRequest(t, "GET")
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		NewResponse(w).
+			DefaultMessage().
+			PermanentRedirect("/users/1", nil)
+	})
+	handler.ServeHTTP(rr, req)
+
+	if err := validateStatusCode(rr.Code, http.StatusPermanentRedirect); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	if err := validateResponseHeader(rr.Header().Get("Location"), "/users/1"); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	expected := `{"status":308,"message":"Permanent Redirect","location":"/users/1"}`
+	if err := validateResponseBody(rr.Body.String(), expected); err != nil {
+		t.Fatal(err.Error())
+	}
+}
+
+func TestStatusBadRequest(t *testing.T) {
+	t.Parallel()
+
+	req := newRequest(t, "GET")
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		NewResponse(w).
+			BadRequest(&Error{400, "Bad Request"})
+	})
+	handler.ServeHTTP(rr, req)
+
+	if err := validateStatusCode(rr.Code, http.StatusBadRequest); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	expected := `{"code":400,"message":"Bad Request"}`
+	if err := validateResponseBody(rr.Body.String(), expected); err != nil {
+		t.Fatal(err.Error())
+	}
+}
+
+func TestStatusUnauthorized(t *testing.T) {
+	t.Parallel()
+
+	req := newRequest(t, "GET")
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		NewResponse(w).
+			Unauthorized(&Error{401, "Unauthorized"})
+	})
+	handler.ServeHTTP(rr, req)
+
+	if err := validateStatusCode(rr.Code, http.StatusUnauthorized); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	expected := `{"code":401,"message":"Unauthorized"}`
+	if err := validateResponseBody(rr.Body.String(), expected); err != nil {
+		t.Fatal(err.Error())
+	}
+}
+
+func TestStatusPaymentRequired(t *testing.T) {
+	t.Parallel()
+
+	req := newRequest(t, "GET")
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		NewResponse(w).
+			PaymentRequired(&Error{402, "Payment Required"})
+	})
+	handler.ServeHTTP(rr, req)
+
+	if err := validateStatusCode(rr.Code, http.StatusPaymentRequired); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	expected := `{"code":402,"message":"Payment Required"}`
+	if err := validateResponseBody(rr.Body.String(), expected); err != nil {
+		t.Fatal(err.Error())
+	}
+}
+
+func TestStatusForbidden(t *testing.T) {
+	t.Parallel()
+
+	req := newRequest(t, "GET")
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		NewResponse(w).
+			Forbidden(&Error{403, "Forbidden"})
+	})
+	handler.ServeHTTP(rr, req)
+
+	if err := validateStatusCode(rr.Code, http.StatusForbidden); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	expected := `{"code":403,"message":"Forbidden"}`
+	if err := validateResponseBody(rr.Body.String(), expected); err != nil {
+		t.Fatal(err.Error())
+	}
+}
+
+func TestStatusNotFound(t *testing.T) {
+	t.Parallel()
+
+	req := newRequest(t, "GET")
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		NewResponse(w).
+			NotFound(&Error{404, "Not Found"})
+	})
+	handler.ServeHTTP(rr, req)
+
+	if err := validateStatusCode(rr.Code, http.StatusNotFound); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	expected := `{"code":404,"message":"Not Found"}`
+	if err := validateResponseBody(rr.Body.String(), expected); err != nil {
+		t.Fatal(err.Error())
+	}
+}
+
+func TestStatusMethodNotAllowed(t *testing.T) {
+	t.Parallel()
+
+	req := newRequest(t, "GET")
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		NewResponse(w).
+			MethodNotAllowed(&Error{405, "Method Not Allowed"})
+	})
+	handler.ServeHTTP(rr, req)
+
+	if err := validateStatusCode(rr.Code, http.StatusMethodNotAllowed); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	expected := `{"code":405,"message":"Method Not Allowed"}`
+	if err := validateResponseBody(rr.Body.String(), expected); err != nil {
+		t.Fatal(err.Error())
+	}
+}
+
+func TestStatusNotAcceptable(t *testing.T) {
+	t.Parallel()
+
+	req := newRequest(t, "GET")
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		NewResponse(w).
+			NotAcceptable(&Error{406, "Not Acceptable"})
+	})
+	handler.ServeHTTP(rr, req)
+
+	if err := validateStatusCode(rr.Code, http.StatusNotAcceptable); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	expected := `{"code":406,"message":"Not Acceptable"}`
+	if err := validateResponseBody(rr.Body.String(), expected); err != nil {
+		t.Fatal(err.Error())
+	}
+}
+
+func TestStatusProxyAuthRequired(t *testing.T) {
+	t.Parallel()
+
+	req := newRequest(t, "GET")
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		NewResponse(w).
+			ProxyAuthRequired(&Error{407, "Proxy Authentication Required"})
+	})
+	handler.ServeHTTP(rr, req)
+
+	if err := validateStatusCode(rr.Code, http.StatusProxyAuthRequired); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	expected := `{"code":407,"message":"Proxy Authentication Required"}`
+	if err := validateResponseBody(rr.Body.String(), expected); err != nil {
+		t.Fatal(err.Error())
+	}
+}
+
+func TestStatusRequestTimeout(t *testing.T) {
+	t.Parallel()
+
+	req := newRequest(t, "GET")
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		NewResponse(w).
+			RequestTimeout(&Error{408, "Request Timeout"})
+	})
+	handler.ServeHTTP(rr, req)
+
+	if err := validateStatusCode(rr.Code, http.StatusRequestTimeout); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	expected := `{"code":408,"message":"Request Timeout"}`
+	if err := validateResponseBody(rr.Body.String(), expected); err != nil {
+		t.Fatal(err.Error())
+	}
+}
+
+func TestStatusConflict(t *testing.T) {
+	t.Parallel()
+
+	req := newRequest(t, "GET")
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		NewResponse(w).
+			Conflict(&Error{409, "Conflict"})
+	})
+	handler.ServeHTTP(rr, req)
+
+	if err := validateStatusCode(rr.Code, http.StatusConflict); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	expected := `{"code":409,"message":"Conflict"}`
+	if err := validateResponseBody(rr.Body.String(), expected); err != nil {
+		t.Fatal(err.Error())
+	}
+}
+
+func TestStatusGone(t *testing.T) {
+	t.Parallel()
+
+	req := newRequest(t, "GET")
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		NewResponse(w).
+			Gone(&Error{410, "Gone"})
+	})
+	handler.ServeHTTP(rr, req)
+
+	if err := validateStatusCode(rr.Code, http.StatusGone); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	expected := `{"code":410,"message":"Gone"}`
+	if err := validateResponseBody(rr.Body.String(), expected); err != nil {
+		t.Fatal(err.Error())
+	}
+}
+
+func TestStatusLengthRequired(t *testing.T) {
+	t.Parallel()
+
+	req := newRequest(t, "GET")
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		NewResponse(w).
+			LengthRequired(&Error{411, "Length Required"})
+	})
+	handler.ServeHTTP(rr, req)
+
+	if err := validateStatusCode(rr.Code, http.StatusLengthRequired); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	expected := `{"code":411,"message":"Length Required"}`
+	if err := validateResponseBody(rr.Body.String(), expected); err != nil {
+		t.Fatal(err.Error())
+	}
+}
+
+func TestStatusPreconditionFailed(t *testing.T) {
+	t.Parallel()
+
+	req := newRequest(t, "GET")
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		NewResponse(w).
+			PreconditionFailed(&Error{412, "Precondition Failed"})
+	})
+	handler.ServeHTTP(rr, req)
+
+	if err := validateStatusCode(rr.Code, http.StatusPreconditionFailed); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	expected := `{"code":412,"message":"Precondition Failed"}`
+	if err := validateResponseBody(rr.Body.String(), expected); err != nil {
+		t.Fatal(err.Error())
+	}
+}
+
+func TestStatusRequestEntityTooLarge(t *testing.T) {
+	t.Parallel()
+
+	req := newRequest(t, "GET")
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		NewResponse(w).
+			RequestEntityTooLarge(&Error{413, "Request Entity Too Large"})
+	})
+	handler.ServeHTTP(rr, req)
+
+	if err := validateStatusCode(rr.Code, http.StatusRequestEntityTooLarge); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	expected := `{"code":413,"message":"Request Entity Too Large"}`
+	if err := validateResponseBody(rr.Body.String(), expected); err != nil {
+		t.Fatal(err.Error())
+	}
+}
+
+func TestStatusRequestURITooLong(t *testing.T) {
+	t.Parallel()
+
+	req := newRequest(t, "GET")
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		NewResponse(w).
+			RequestURITooLong(&Error{414, "Request URI Too Long"})
+	})
+	handler.ServeHTTP(rr, req)
+
+	if err := validateStatusCode(rr.Code, http.StatusRequestURITooLong); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	expected := `{"code":414,"message":"Request URI Too Long"}`
+	if err := validateResponseBody(rr.Body.String(), expected); err != nil {
+		t.Fatal(err.Error())
+	}
+}
+
+func TestStatusUnsupportedMediaType(t *testing.T) {
+	t.Parallel()
+
+	req := newRequest(t, "GET")
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		NewResponse(w).
+			UnsupportedMediaType(&Error{415, "Unsupported Media Type"})
+	})
+	handler.ServeHTTP(rr, req)
+
+	if err := validateStatusCode(rr.Code, http.StatusUnsupportedMediaType); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	expected := `{"code":415,"message":"Unsupported Media Type"}`
+	if err := validateResponseBody(rr.Body.String(), expected); err != nil {
+		t.Fatal(err.Error())
+	}
+}
+
+func TestStatusRequestedRangeNotSatisfiable(t *testing.T) {
+	t.Parallel()
+
+	req := newRequest(t, "GET")
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		NewResponse(w).
+			RequestedRangeNotSatisfiable(&Error{416, "Requested Range Not Satisfiable"})
+	})
+	handler.ServeHTTP(rr, req)
+
+	if err := validateStatusCode(rr.Code, http.StatusRequestedRangeNotSatisfiable); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	expected := `{"code":416,"message":"Requested Range Not Satisfiable"}`
+	if err := validateResponseBody(rr.Body.String(), expected); err != nil {
+		t.Fatal(err.Error())
+	}
+}
+
+func TestStatusExpectationFailed(t *testing.T) {
+	t.Parallel()
+
+	req := newRequest(t, "GET")
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		NewResponse(w).
+			ExpectationFailed(&Error{417, "Expectation Failed"})
+	})
+	handler.ServeHTTP(rr, req)
+
+	if err := validateStatusCode(rr.Code, http.StatusExpectationFailed); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	expected := `{"code":417,"message":"Expectation Failed"}`
+	if err := validateResponseBody(rr.Body.String(), expected); err != nil {
+		t.Fatal(err.Error())
+	}
+}
+
+func TestStatusMisdirectedRequest(t *testing.T) {
+	t.Parallel()
+
+	req := newRequest(t, "GET")
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		NewResponse(w).
+			MisdirectedRequest(&Error{421, "Misdirected Request"})
+	})
+	handler.ServeHTTP(rr, req)
+
+	if err := validateStatusCode(rr.Code, http.StatusMisdirectedRequest); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	expected := `{"code":421,"message":"Misdirected Request"}`
+	if err := validateResponseBody(rr.Body.String(), expected); err != nil {
+		t.Fatal(err.Error())
+	}
+}
+
+func TestStatusUnprocessableEntity(t *testing.T) {
+	t.Parallel()
+
+	req := newRequest(t, "GET")
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		NewResponse(w).
+			UnprocessableEntity(&Error{422, "Unprocessable Entity"})
+	})
+	handler.ServeHTTP(rr, req)
+
+	if err := validateStatusCode(rr.Code, http.StatusUnprocessableEntity); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	expected := `{"code":422,"message":"Unprocessable Entity"}`
+	if err := validateResponseBody(rr.Body.String(), expected); err != nil {
+		t.Fatal(err.Error())
+	}
+}
+
+func TestStatusLocked(t *testing.T) {
+	t.Parallel()
+
+	req := newRequest(t, "GET")
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		NewResponse(w).
+			Locked(&Error{423, "Locked"})
+	})
+	handler.ServeHTTP(rr, req)
+
+	if err := validateStatusCode(rr.Code, http.StatusLocked); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	expected := `{"code":423,"message":"Locked"}`
+	if err := validateResponseBody(rr.Body.String(), expected); err != nil {
+		t.Fatal(err.Error())
+	}
+}
+
+func TestStatusFailedDependency(t *testing.T) {
+	t.Parallel()
+
+	req := newRequest(t, "GET")
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		NewResponse(w).
+			FailedDependency(&Error{424, "Failed Dependency"})
+	})
+	handler.ServeHTTP(rr, req)
+
+	if err := validateStatusCode(rr.Code, http.StatusFailedDependency); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	expected := `{"code":424,"message":"Failed Dependency"}`
+	if err := validateResponseBody(rr.Body.String(), expected); err != nil {
+		t.Fatal(err.Error())
+	}
+}
+
+func TestStatusTooEarly(t *testing.T) {
+	t.Parallel()
+
+	req := newRequest(t, "GET")
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		NewResponse(w).
+			TooEarly(&Error{425, "Too Early"})
+	})
+	handler.ServeHTTP(rr, req)
+
+	if err := validateStatusCode(rr.Code, http.StatusTooEarly); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	expected := `{"code":425,"message":"Too Early"}`
+	if err := validateResponseBody(rr.Body.String(), expected); err != nil {
+		t.Fatal(err.Error())
+	}
+}
+
+func TestStatusUpgradeRequired(t *testing.T) {
+	t.Parallel()
+
+	req := newRequest(t, "GET")
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		NewResponse(w).
+			UpgradeRequired(&Error{426, "Upgrade Required"})
+	})
+	handler.ServeHTTP(rr, req)
+
+	if err := validateStatusCode(rr.Code, http.StatusUpgradeRequired); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	expected := `{"code":426,"message":"Upgrade Required"}`
+	if err := validateResponseBody(rr.Body.String(), expected); err != nil {
+		t.Fatal(err.Error())
+	}
+}
+
+func TestStatusPreconditionRequired(t *testing.T) {
+	t.Parallel()
+
+	req := newRequest(t, "GET")
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		NewResponse(w).
+			PreconditionRequired(&Error{428, "Precondition Required"})
+	})
+	handler.ServeHTTP(rr, req)
+
+	if err := validateStatusCode(rr.Code, http.StatusPreconditionRequired); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	expected := `{"code":428,"message":"Precondition Required"}`
+	if err := validateResponseBody(rr.Body.String(), expected); err != nil {
+		t.Fatal(err.Error())
+	}
+}
+
+func TestStatusTooManyRequests(t *testing.T) {
+	t.Parallel()
+
+	req := newRequest(t, "GET")
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		NewResponse(w).
+			TooManyRequests(&Error{429, "Too Many Requests"})
+	})
+	handler.ServeHTTP(rr, req)
+
+	if err := validateStatusCode(rr.Code, http.StatusTooManyRequests); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	expected := `{"code":429,"message":"Too Many Requests"}`
+	if err := validateResponseBody(rr.Body.String(), expected); err != nil {
+		t.Fatal(err.Error())
+	}
+}
+
+func TestStatusRequestHeaderFieldsTooLarge(t *testing.T) {
+	t.Parallel()
+
+	req := newRequest(t, "GET")
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		NewResponse(w).
+			RequestHeaderFieldsTooLarge(&Error{431, "Request Header Fields Too Large"})
+	})
+	handler.ServeHTTP(rr, req)
+
+	if err := validateStatusCode(rr.Code, http.StatusRequestHeaderFieldsTooLarge); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	expected := `{"code":431,"message":"Request Header Fields Too Large"}`
+	if err := validateResponseBody(rr.Body.String(), expected); err != nil {
+		t.Fatal(err.Error())
+	}
+}
+
+func TestStatusUnavailableForLegalReasons(t *testing.T) {
+	t.Parallel()
+
+	req := newRequest(t, "GET")
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		NewResponse(w).
+			UnavailableForLegalReasons(&Error{451, "Unavailable For Legal Reasons"})
+	})
+	handler.ServeHTTP(rr, req)
+
+	if err := validateStatusCode(rr.Code, http.StatusUnavailableForLegalReasons); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	expected := `{"code":451,"message":"Unavailable For Legal Reasons"}`
+	if err := validateResponseBody(rr.Body.String(), expected); err != nil {
+		t.Fatal(err.Error())
+	}
+}
+
+func TestStatusInternalServerError(t *testing.T) {
+	t.Parallel()
+
+	req := newRequest(t, "GET")
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		NewResponse(w).
+			InternalServerError(&Error{500, "Internal Server Error"})
+	})
+	handler.ServeHTTP(rr, req)
+
+	if err := validateStatusCode(rr.Code, http.StatusInternalServerError); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	expected := `{"code":500,"message":"Internal Server Error"}`
+	if err := validateResponseBody(rr.Body.String(), expected); err != nil {
+		t.Fatal(err.Error())
+	}
+}
+
+func TestStatusNotImplemented(t *testing.T) {
+	t.Parallel()
+
+	req := newRequest(t, "GET")
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		NewResponse(w).
+			NotImplemented(&Error{501, "Not Implemented"})
+	})
+	handler.ServeHTTP(rr, req)
+
+	if err := validateStatusCode(rr.Code, http.StatusNotImplemented); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	expected := `{"code":501,"message":"Not Implemented"}`
+	if err := validateResponseBody(rr.Body.String(), expected); err != nil {
+		t.Fatal(err.Error())
+	}
+}
+
+func TestStatusBadGateway(t *testing.T) {
+	t.Parallel()
+
+	req := newRequest(t, "GET")
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		NewResponse(w).
+			BadGateway(&Error{502, "Bad Gateway"})
+	})
+	handler.ServeHTTP(rr, req)
+
+	if err := validateStatusCode(rr.Code, http.StatusBadGateway); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	expected := `{"code":502,"message":"Bad Gateway"}`
+	if err := validateResponseBody(rr.Body.String(), expected); err != nil {
+		t.Fatal(err.Error())
+	}
+}
+
+func TestStatusServiceUnavailable(t *testing.T) {
+	t.Parallel()
+
+	req := newRequest(t, "GET")
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		NewResponse(w).
+			ServiceUnavailable(&Error{503, "Service Unavailable"})
+	})
+	handler.ServeHTTP(rr, req)
+
+	if err := validateStatusCode(rr.Code, http.StatusServiceUnavailable); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	expected := `{"code":503,"message":"Service Unavailable"}`
+	if err := validateResponseBody(rr.Body.String(), expected); err != nil {
+		t.Fatal(err.Error())
+	}
+}
+
+func TestStatusGatewayTimeout(t *testing.T) {
+	t.Parallel()
+
+	req := newRequest(t, "GET")
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		NewResponse(w).
+			GatewayTimeout(&Error{504, "Gateway Timeout"})
+	})
+	handler.ServeHTTP(rr, req)
+
+	if err := validateStatusCode(rr.Code, http.StatusGatewayTimeout); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	expected := `{"code":504,"message":"Gateway Timeout"}`
+	if err := validateResponseBody(rr.Body.String(), expected); err != nil {
+		t.Fatal(err.Error())
+	}
+}
+
+func TestStatusHTTPVersionNotSupported(t *testing.T) {
+	t.Parallel()
+
+	req := newRequest(t, "GET")
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		NewResponse(w).
+			HTTPVersionNotSupported(&Error{505, "HTTP Version Not Supported"})
+	})
+	handler.ServeHTTP(rr, req)
+
+	if err := validateStatusCode(rr.Code, http.StatusHTTPVersionNotSupported); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	expected := `{"code":505,"message":"HTTP Version Not Supported"}`
+	if err := validateResponseBody(rr.Body.String(), expected); err != nil {
+		t.Fatal(err.Error())
+	}
+}
+
+func TestStatusVariantAlsoNegotiates(t *testing.T) {
+	t.Parallel()
+
+	req := newRequest(t, "GET")
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		NewResponse(w).
+			VariantAlsoNegotiates(&Error{506, "Variant Also Negotiates"})
+	})
+	handler.ServeHTTP(rr, req)
+
+	if err := validateStatusCode(rr.Code, http.StatusVariantAlsoNegotiates); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	expected := `{"code":506,"message":"Variant Also Negotiates"}`
+	if err := validateResponseBody(rr.Body.String(), expected); err != nil {
+		t.Fatal(err.Error())
+	}
+}
+
+func TestStatusInsufficientStorage(t *testing.T) {
+	t.Parallel()
+
+	req := newRequest(t, "GET")
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		NewResponse(w).
+			InsufficientStorage(&Error{507, "Insufficient Storage"})
+	})
+	handler.ServeHTTP(rr, req)
+
+	if err := validateStatusCode(rr.Code, http.StatusInsufficientStorage); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	expected := `{"code":507,"message":"Insufficient Storage"}`
+	if err := validateResponseBody(rr.Body.String(), expected); err != nil {
+		t.Fatal(err.Error())
+	}
+}
+
+func TestStatusLoopDetected(t *testing.T) {
+	t.Parallel()
+
+	req := newRequest(t, "GET")
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		NewResponse(w).
+			LoopDetected(&Error{508, "Loop Detected"})
+	})
+	handler.ServeHTTP(rr, req)
+
+	if err := validateStatusCode(rr.Code, http.StatusLoopDetected); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	expected := `{"code":508,"message":"Loop Detected"}`
+	if err := validateResponseBody(rr.Body.String(), expected); err != nil {
+		t.Fatal(err.Error())
+	}
+}
+
+func TestStatusNotExtended(t *testing.T) {
+	t.Parallel()
+
+	req := newRequest(t, "GET")
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		NewResponse(w).
+			NotExtended(&Error{510, "Not Extended"})
+	})
+	handler.ServeHTTP(rr, req)
+
+	if err := validateStatusCode(rr.Code, http.StatusNotExtended); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	expected := `{"code":510,"message":"Not Extended"}`
+	if err := validateResponseBody(rr.Body.String(), expected); err != nil {
+		t.Fatal(err.Error())
+	}
+}
+
+func TestStatusNetworkAuthenticationRequired(t *testing.T) {
+	t.Parallel()
+
+	req := newRequest(t, "GET")
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		NewResponse(w).
+			NetworkAuthenticationRequired(&Error{511, "Network Authentication Required"})
+	})
+	handler.ServeHTTP(rr, req)
+
+	if err := validateStatusCode(rr.Code, http.StatusNetworkAuthenticationRequired); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	expected := `{"code":511,"message":"Network Authentication Required"}`
+	if err := validateResponseBody(rr.Body.String(), expected); err != nil {
+		t.Fatal(err.Error())
+	}
+}
@@ -0,0 +1,244 @@
+package respond
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"reflect"
+	"sort"
+)
+
+// DefaultFlushEvery is the number of values written to a stream between
+// flushes when the response does not set FlushEvery
+const DefaultFlushEvery = 64
+
+// ErrInvalidStreamSource is returned when a stream is given a source that is
+// neither a receivable channel nor an iterator function
+var ErrInvalidStreamSource = errors.New("respond: stream source must be a channel or an iterator function")
+
+// FlushAfter sets the number of values written to a stream between flushes
+func (resp *Response) FlushAfter(n int) *Response {
+	resp.FlushEvery = n
+	return resp
+}
+
+// Stream responds with a 200 OK newline delimited JSON (application/x-ndjson)
+// stream, writing each value produced by src on its own line. src is a
+// channel or an iterator function of the form func(yield func(T) bool), such
+// as iter.Seq. The stream is flushed every FlushEvery values and when src is
+// exhausted, and stops early when the request context is done, returning
+// the context's error.
+func (resp *Response) Stream(src interface{}) error {
+	if !validStreamSource(src) {
+		resp.InternalServerError(nil)
+		return ErrInvalidStreamSource
+	}
+
+	resp.writeHeaders(http.StatusOK, "application/x-ndjson")
+	resp.writeStatusCode(http.StatusOK)
+
+	flusher := resp.newStreamFlusher()
+	err := each(resp.context(), src, func(v interface{}) error {
+		line, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		if _, err := resp.Writer.Write(append(line, '\n')); err != nil {
+			return err
+		}
+		flusher.wrote()
+		return nil
+	})
+	flusher.flush()
+
+	return err
+}
+
+// StreamArray responds with a 200 OK JSON array, written incrementally from
+// the values produced by src, so that large collections are sent without
+// being held in memory. src is a channel or an iterator function, as for
+// Stream. If src is interrupted the array is left unterminated, so clients
+// do not mistake a partial result for a complete one.
+func (resp *Response) StreamArray(src interface{}) error {
+	return resp.streamArray(nil, src)
+}
+
+// StreamEnvelope is like StreamArray, but wraps the array in a JSON object
+// under key. The other members of the object are written first, in key
+// order.
+func (resp *Response) StreamEnvelope(key string, members map[string]interface{}, src interface{}) error {
+	keys := make([]string, 0, len(members))
+	for k := range members {
+		if k != key {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	var prefix bytes.Buffer
+	prefix.WriteByte('{')
+	for _, k := range keys {
+		name, _ := json.Marshal(k)
+		value, err := json.Marshal(members[k])
+		if err != nil {
+			resp.InternalServerError(nil)
+			return err
+		}
+		prefix.Write(name)
+		prefix.WriteByte(':')
+		prefix.Write(value)
+		prefix.WriteByte(',')
+	}
+	name, _ := json.Marshal(key)
+	prefix.Write(name)
+	prefix.WriteByte(':')
+
+	return resp.streamArray(prefix.Bytes(), src)
+}
+
+// streamArray writes the values from src as a JSON array, preceded by
+// prefix. An object opened by the prefix is closed after the array.
+func (resp *Response) streamArray(prefix []byte, src interface{}) error {
+	if !validStreamSource(src) {
+		resp.InternalServerError(nil)
+		return ErrInvalidStreamSource
+	}
+
+	resp.writeHeaders(http.StatusOK, JSONEncoder{}.ContentType())
+	resp.writeStatusCode(http.StatusOK)
+
+	if _, err := resp.Writer.Write(append(prefix, '[')); err != nil {
+		return err
+	}
+
+	flusher := resp.newStreamFlusher()
+	first := true
+	err := each(resp.context(), src, func(v interface{}) error {
+		element, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		if !first {
+			element = append([]byte{','}, element...)
+		}
+		first = false
+		if _, err := resp.Writer.Write(element); err != nil {
+			return err
+		}
+		flusher.wrote()
+		return nil
+	})
+
+	if err == nil {
+		end := []byte{']'}
+		if len(prefix) > 0 {
+			end = append(end, '}')
+		}
+		_, err = resp.Writer.Write(end)
+	}
+	flusher.flush()
+
+	return err
+}
+
+// context returns the context of the request, if any
+func (resp *Response) context() context.Context {
+	if resp.Request == nil {
+		return context.Background()
+	}
+	return resp.Request.Context()
+}
+
+// streamFlusher flushes the response after every n values written
+type streamFlusher struct {
+	flusher http.Flusher
+	every   int
+	pending int
+}
+
+func (resp *Response) newStreamFlusher() *streamFlusher {
+	every := resp.FlushEvery
+	if every <= 0 {
+		every = DefaultFlushEvery
+	}
+	flusher, _ := resp.Writer.(http.Flusher)
+	return &streamFlusher{flusher: flusher, every: every}
+}
+
+func (f *streamFlusher) wrote() {
+	f.pending++
+	if f.pending >= f.every {
+		f.flush()
+	}
+}
+
+func (f *streamFlusher) flush() {
+	if f.flusher != nil {
+		f.flusher.Flush()
+	}
+	f.pending = 0
+}
+
+var boolType = reflect.TypeOf(true)
+
+// validStreamSource reports whether src is a receivable channel or an
+// iterator function
+func validStreamSource(src interface{}) bool {
+	rv := reflect.ValueOf(src)
+	switch rv.Kind() {
+	case reflect.Chan:
+		return rv.Type().ChanDir()&reflect.RecvDir != 0
+	case reflect.Func:
+		t := rv.Type()
+		if rv.IsNil() || t.NumIn() != 1 || t.NumOut() != 0 {
+			return false
+		}
+		yield := t.In(0)
+		return yield.Kind() == reflect.Func && yield.NumIn() == 1 &&
+			yield.NumOut() == 1 && yield.Out(0) == boolType
+	}
+	return false
+}
+
+// each calls fn with every value produced by src, a channel or an iterator
+// function, until src is exhausted, fn returns an error or ctx is done
+func each(ctx context.Context, src interface{}, fn func(v interface{}) error) error {
+	if !validStreamSource(src) {
+		return ErrInvalidStreamSource
+	}
+
+	rv := reflect.ValueOf(src)
+	if rv.Kind() == reflect.Chan {
+		cases := []reflect.SelectCase{
+			{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(ctx.Done())},
+			{Dir: reflect.SelectRecv, Chan: rv},
+		}
+		for {
+			chosen, v, ok := reflect.Select(cases)
+			if chosen == 0 {
+				return ctx.Err()
+			}
+			if !ok {
+				return nil
+			}
+			if err := fn(v.Interface()); err != nil {
+				return err
+			}
+		}
+	}
+
+	var err error
+	yield := reflect.MakeFunc(rv.Type().In(0), func(args []reflect.Value) []reflect.Value {
+		if err == nil {
+			if err = ctx.Err(); err == nil {
+				err = fn(args[0].Interface())
+			}
+		}
+		return []reflect.Value{reflect.ValueOf(err == nil)}
+	})
+	rv.Call([]reflect.Value{yield})
+
+	return err
+}
@@ -0,0 +1,219 @@
+package respond
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func userSeq(n int) func(yield func(User) bool) {
+	return func(yield func(User) bool) {
+		for i := 1; i <= n; i++ {
+			if !yield(User{i, "Billy", "billy@example.com"}) {
+				return
+			}
+		}
+	}
+}
+
+func TestStreamChannel(t *testing.T) {
+	t.Parallel()
+
+	req := newRequest(t, "GET")
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		users := make(chan User)
+		go func() {
+			defer close(users)
+			users <- User{1, "Billy", "billy@example.com"}
+			users <- User{2, "Joan", "joan@example.com"}
+		}()
+
+		if err := NewResponse(w).WithRequest(r).Stream(users); err != nil {
+			t.Error(err)
+		}
+	})
+	handler.ServeHTTP(rr, req)
+
+	if err := validateStatusCode(rr.Code, http.StatusOK); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := validateResponseHeader(rr.Header().Get("Content-Type"), "application/x-ndjson"); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `{"id":1,"name":"Billy","email":"billy@example.com"}` + "\n" +
+		`{"id":2,"name":"Joan","email":"joan@example.com"}` + "\n"
+	if err := validateResponseBody(rr.Body.String(), expected); err != nil {
+		t.Fatal(err)
+	}
+
+	if !rr.Flushed {
+		t.Fatal("expected the stream to be flushed")
+	}
+}
+
+func TestStreamIterator(t *testing.T) {
+	t.Parallel()
+
+	req := newRequest(t, "GET")
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := NewResponse(w).FlushAfter(1).Stream(userSeq(2)); err != nil {
+			t.Error(err)
+		}
+	})
+	handler.ServeHTTP(rr, req)
+
+	expected := `{"id":1,"name":"Billy","email":"billy@example.com"}` + "\n" +
+		`{"id":2,"name":"Billy","email":"billy@example.com"}` + "\n"
+	if err := validateResponseBody(rr.Body.String(), expected); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestStreamCancelled(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	req := newRequest(t, "GET").WithContext(ctx)
+
+	var respErr error
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seq := func(yield func(User) bool) {
+			for i := 1; ; i++ {
+				if i == 3 {
+					cancel()
+				}
+				if !yield(User{i, "Billy", "billy@example.com"}) {
+					return
+				}
+			}
+		}
+		respErr = NewResponse(w).WithRequest(r).Stream(seq)
+	})
+	handler.ServeHTTP(rr, req)
+
+	if respErr != context.Canceled {
+		t.Fatalf("expected context.Canceled, got %v", respErr)
+	}
+
+	expected := `{"id":1,"name":"Billy","email":"billy@example.com"}` + "\n" +
+		`{"id":2,"name":"Billy","email":"billy@example.com"}` + "\n"
+	if err := validateResponseBody(rr.Body.String(), expected); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestStreamInvalidSource(t *testing.T) {
+	t.Parallel()
+
+	req := newRequest(t, "GET")
+
+	var respErr error
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		respErr = NewResponse(w).Stream([]User{{1, "Billy", "billy@example.com"}})
+	})
+	handler.ServeHTTP(rr, req)
+
+	if respErr != ErrInvalidStreamSource {
+		t.Fatalf("expected ErrInvalidStreamSource, got %v", respErr)
+	}
+
+	if err := validateStatusCode(rr.Code, http.StatusInternalServerError); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestStreamArray(t *testing.T) {
+	t.Parallel()
+
+	req := newRequest(t, "GET")
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := NewResponse(w).WithRequest(r).StreamArray(userSeq(2)); err != nil {
+			t.Error(err)
+		}
+	})
+	handler.ServeHTTP(rr, req)
+
+	if err := validateResponseHeader(rr.Header().Get("Content-Type"), "application/json; charset=utf-8"); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `[{"id":1,"name":"Billy","email":"billy@example.com"},` +
+		`{"id":2,"name":"Billy","email":"billy@example.com"}]`
+	if err := validateResponseBody(rr.Body.String(), expected); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestStreamArrayEmpty(t *testing.T) {
+	t.Parallel()
+
+	req := newRequest(t, "GET")
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		NewResponse(w).StreamArray(userSeq(0))
+	})
+	handler.ServeHTTP(rr, req)
+
+	if err := validateResponseBody(rr.Body.String(), `[]`); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestStreamEnvelope(t *testing.T) {
+	t.Parallel()
+
+	req := newRequest(t, "GET")
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		members := map[string]interface{}{
+			"meta": map[string]int{"total": 1},
+			"data": "replaced by the array",
+		}
+		NewResponse(w).StreamEnvelope("data", members, userSeq(1))
+	})
+	handler.ServeHTTP(rr, req)
+
+	expected := `{"meta":{"total":1},"data":[{"id":1,"name":"Billy","email":"billy@example.com"}]}`
+	if err := validateResponseBody(rr.Body.String(), expected); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestStreamArrayInterrupted(t *testing.T) {
+	t.Parallel()
+
+	req := newRequest(t, "GET")
+
+	var respErr error
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seq := func(yield func(interface{}) bool) {
+			if yield(1) {
+				yield(make(chan int))
+			}
+		}
+		respErr = NewResponse(w).StreamArray(seq)
+	})
+	handler.ServeHTTP(rr, req)
+
+	if respErr == nil {
+		t.Fatal("expected an encoding error")
+	}
+
+	if err := validateResponseBody(rr.Body.String(), `[1`); err != nil {
+		t.Fatal(err)
+	}
+}
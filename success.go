@@ -1,25 +1,55 @@
+// Code generated by gen_status.go. DO NOT EDIT.
+
 package respond
 
-import (
-	"net/http"
-)
+import "net/http"
 
 // Ok returns a 200 OK JSON response
-func (resp *Response) Ok(v interface{}) {
-	resp.writeResponse(http.StatusOK, v)
+func (resp *Response) Ok(v interface{}) error {
+	return resp.writeResponse(http.StatusOK, v)
 }
 
 // Created returns a 201 Created JSON response
-func (resp *Response) Created(v interface{}) {
-	resp.writeResponse(http.StatusCreated, v)
+func (resp *Response) Created(v interface{}) error {
+	return resp.writeResponse(http.StatusCreated, v)
 }
 
 // Accepted returns a 202 Accepted JSON response
-func (resp *Response) Accepted(v interface{}) {
-	resp.writeResponse(http.StatusAccepted, v)
+func (resp *Response) Accepted(v interface{}) error {
+	return resp.writeResponse(http.StatusAccepted, v)
+}
+
+// NonAuthoritativeInfo returns a 203 Non-Authoritative Information JSON response
+func (resp *Response) NonAuthoritativeInfo(v interface{}) error {
+	return resp.writeResponse(http.StatusNonAuthoritativeInfo, v)
+}
+
+// NoContent returns a 204 No Content response
+func (resp *Response) NoContent() error {
+	return resp.writeResponse(http.StatusNoContent, nil)
+}
+
+// ResetContent returns a 205 Reset Content response
+func (resp *Response) ResetContent() error {
+	return resp.writeResponse(http.StatusResetContent, nil)
+}
+
+// PartialContent returns a 206 Partial Content JSON response
+func (resp *Response) PartialContent(v interface{}) error {
+	return resp.writeResponse(http.StatusPartialContent, v)
+}
+
+// MultiStatus returns a 207 Multi-Status JSON response
+func (resp *Response) MultiStatus(v interface{}) error {
+	return resp.writeResponse(http.StatusMultiStatus, v)
+}
+
+// AlreadyReported returns a 208 Already Reported JSON response
+func (resp *Response) AlreadyReported(v interface{}) error {
+	return resp.writeResponse(http.StatusAlreadyReported, v)
 }
 
-// NoContent returns a 204 No Content JSON response
-func (resp *Response) NoContent() {
-	resp.writeResponse(http.StatusNoContent, nil)
+// IMUsed returns a 226 IM Used JSON response
+func (resp *Response) IMUsed(v interface{}) error {
+	return resp.writeResponse(http.StatusIMUsed, v)
 }
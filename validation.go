@@ -0,0 +1,134 @@
+package respond
+
+import (
+	"encoding/xml"
+	"net/http"
+	"strings"
+)
+
+// FieldError describes a field of a request that failed validation
+type FieldError struct {
+	// Pointer is the JSON pointer to the field, e.g. /address/street
+	Pointer string `json:"pointer" xml:"pointer"`
+	// Rule names the validation rule that failed, e.g. required
+	Rule string `json:"rule,omitempty" xml:"rule,omitempty"`
+	// Detail is a human readable description of the failure
+	Detail string `json:"detail" xml:"detail"`
+	// Value is the rejected value
+	Value interface{} `json:"value,omitempty" xml:"value,omitempty"`
+}
+
+// MarshalXML encodes the field error, with a map value encoded as an
+// element per key
+func (f FieldError) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return e.EncodeElement(struct {
+		Pointer string      `xml:"pointer"`
+		Rule    string      `xml:"rule,omitempty"`
+		Detail  string      `xml:"detail"`
+		Value   interface{} `xml:"value,omitempty"`
+	}{f.Pointer, f.Rule, f.Detail, xmlMap(f.Value)}, start)
+}
+
+// ValidationErrors collects the fields of a request that failed validation.
+// Send it with UnprocessableEntity or BadRequest, or as a problem document
+// with Problem. Responses with DefaultProblem send it as a problem document
+// holding the fields under errors.
+type ValidationErrors []FieldError
+
+// Add records a field that failed validation. The field is a dotted path,
+// e.g. address.street or items[0].name, or a JSON pointer.
+func (v *ValidationErrors) Add(field, rule, detail string, value interface{}) *ValidationErrors {
+	*v = append(*v, FieldError{
+		Pointer: jsonPointer(field),
+		Rule:    rule,
+		Detail:  detail,
+		Value:   value,
+	})
+	return v
+}
+
+// Err returns the errors as an error, or nil when no field failed
+func (v ValidationErrors) Err() error {
+	if len(v) == 0 {
+		return nil
+	}
+	return v
+}
+
+// Error lists the fields that failed validation
+func (v ValidationErrors) Error() string {
+	fields := make([]string, len(v))
+	for i, e := range v {
+		fields[i] = e.Pointer + ": " + e.Detail
+	}
+	return "respond: validation failed: " + strings.Join(fields, "; ")
+}
+
+// Problem returns a problem document for the status, holding the fields
+// under the errors extension member
+func (v ValidationErrors) Problem(status int) *Problem {
+	return NewProblem(status, "").With("errors", v)
+}
+
+// problem converts the errors into the problem document sent by responses
+// with DefaultProblem
+func (v ValidationErrors) problem(code int) Problem {
+	return *v.Problem(code)
+}
+
+// MarshalXML encodes the fields as error elements of an errors element
+func (v ValidationErrors) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	start = xml.StartElement{Name: xml.Name{Local: "errors"}}
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+
+	for _, field := range v {
+		if err := e.EncodeElement(field, xml.StartElement{Name: xml.Name{Local: "error"}}); err != nil {
+			return err
+		}
+	}
+
+	return e.EncodeToken(start.End())
+}
+
+// jsonapiErrors converts the fields into error objects pointing into the
+// attributes of the request document, titled with the status text
+func (v ValidationErrors) jsonapiErrors(code int) []*JSONAPIError {
+	errs := make([]*JSONAPIError, len(v))
+	for i, field := range v {
+		errs[i] = &JSONAPIError{
+			Code:   field.Rule,
+			Title:  http.StatusText(code),
+			Detail: field.Detail,
+			Source: &JSONAPIErrorSource{Pointer: "/data/attributes" + field.Pointer},
+		}
+	}
+	return errs
+}
+
+// jsonPointer converts a dotted field path, with indexes either dotted or
+// in brackets, into a JSON pointer. Fields that already are JSON pointers are
+// returned as they are.
+func jsonPointer(field string) string {
+	if field == "" || strings.HasPrefix(field, "/") {
+		return field
+	}
+
+	field = strings.NewReplacer("[", ".", "]", "").Replace(field)
+
+	var b strings.Builder
+	for _, token := range strings.Split(field, ".") {
+		if token == "" {
+			continue
+		}
+		b.WriteByte('/')
+		b.WriteString(escapePointer(token))
+	}
+	return b.String()
+}
+
+// escapePointer escapes a reference token of a JSON pointer
+func escapePointer(token string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(token)
+}
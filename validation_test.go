@@ -0,0 +1,128 @@
+package respond
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+var jsonPointerData = []struct {
+	field    string
+	expected string
+}{
+	{"name", "/name"},
+	{"address.street", "/address/street"},
+	{"items[0].name", "/items/0/name"},
+	{"items.1", "/items/1"},
+	{"a/b~c", "/a~1b~0c"},
+	{"/already/a/pointer", "/already/a/pointer"},
+}
+
+func TestJSONPointer(t *testing.T) {
+	for _, datum := range jsonPointerData {
+		if got := jsonPointer(datum.field); got != datum.expected {
+			t.Errorf("jsonPointer(%q) = %q, wanted %q", datum.field, got, datum.expected)
+		}
+	}
+}
+
+func newValidationErrors() ValidationErrors {
+	var errs ValidationErrors
+	errs.Add("name", "required", "Name is required", nil).
+		Add("items[0].quantity", "min", "Quantity must be at least 1", 0)
+	return errs
+}
+
+var validationData = []struct {
+	testName string
+
+	respond func(resp *Response) error
+
+	expectedStatus      int
+	expectedContentType string
+	expectedBody        string
+}{
+	{"unprocessable entity",
+		func(resp *Response) error { return resp.UnprocessableEntity(newValidationErrors()) },
+		http.StatusUnprocessableEntity, "application/json; charset=utf-8",
+		`[{"pointer":"/name","rule":"required","detail":"Name is required"},` +
+			`{"pointer":"/items/0/quantity","rule":"min","detail":"Quantity must be at least 1","value":0}]`},
+	{"problem",
+		func(resp *Response) error { return resp.Problem(newValidationErrors().Problem(http.StatusBadRequest)) },
+		http.StatusBadRequest, "application/problem+json",
+		`{"title":"Bad Request","status":400,"errors":[{"pointer":"/name","rule":"required","detail":"Name is required"},` +
+			`{"pointer":"/items/0/quantity","rule":"min","detail":"Quantity must be at least 1","value":0}]}`},
+	{"default problem",
+		func(resp *Response) error { return resp.DefaultProblem().UnprocessableEntity(newValidationErrors()) },
+		http.StatusUnprocessableEntity, "application/problem+json",
+		`{"title":"Unprocessable Entity","status":422,"errors":[{"pointer":"/name","rule":"required","detail":"Name is required"},` +
+			`{"pointer":"/items/0/quantity","rule":"min","detail":"Quantity must be at least 1","value":0}]}`},
+	{"json:api",
+		func(resp *Response) error { return resp.JSONAPI().UnprocessableEntity(newValidationErrors()) },
+		http.StatusUnprocessableEntity, "application/vnd.api+json",
+		`{"errors":[{"status":"422","code":"required","title":"Unprocessable Entity","detail":"Name is required","source":{"pointer":"/data/attributes/name"}},` +
+			`{"status":"422","code":"min","title":"Unprocessable Entity","detail":"Quantity must be at least 1","source":{"pointer":"/data/attributes/items/0/quantity"}}]}`},
+	{"xml",
+		func(resp *Response) error {
+			return resp.WithEncoder(XMLEncoder{}).BadRequest(newValidationErrors()[:1])
+		},
+		http.StatusBadRequest, "application/xml; charset=utf-8",
+		`<?xml version="1.0" encoding="UTF-8"?>` + "\n" +
+			`<errors><error><pointer>/name</pointer><rule>required</rule><detail>Name is required</detail>` +
+			`</error></errors>`},
+	{"xml map value",
+		func(resp *Response) error {
+			var errs ValidationErrors
+			errs.Add("address", "complete", "Address is incomplete", map[string]string{"city": "Springfield"})
+			return resp.WithEncoder(XMLEncoder{}).UnprocessableEntity(errs)
+		},
+		http.StatusUnprocessableEntity, "application/xml; charset=utf-8",
+		`<?xml version="1.0" encoding="UTF-8"?>` + "\n" +
+			`<errors><error><pointer>/address</pointer><rule>complete</rule><detail>Address is incomplete</detail>` +
+			`<value><city>Springfield</city></value></error></errors>`},
+}
+
+func TestValidationErrors(t *testing.T) {
+	for _, datum := range validationData {
+		datum := datum
+		t.Run(datum.testName, func(t *testing.T) {
+			t.Parallel()
+
+			req := newRequest(t, "GET")
+
+			rr := httptest.NewRecorder()
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				datum.respond(NewResponse(w))
+			})
+			handler.ServeHTTP(rr, req)
+
+			if err := validateStatusCode(rr.Code, datum.expectedStatus); err != nil {
+				t.Fatal(err)
+			}
+
+			if err := validateResponseHeader(rr.Header().Get("Content-Type"), datum.expectedContentType); err != nil {
+				t.Fatal(err)
+			}
+
+			if err := validateResponseBody(rr.Body.String(), datum.expectedBody); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestValidationErrorsErr(t *testing.T) {
+	var errs ValidationErrors
+	if err := errs.Err(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	errs.Add("email", "email", "Email is invalid", "billy")
+	err := errs.Err()
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if expected := "respond: validation failed: /email: Email is invalid"; err.Error() != expected {
+		t.Fatalf("unexpected error message: got %q wanted %q", err.Error(), expected)
+	}
+}